@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// anonymize pseudonymizes the database copy named by --anonymize-target. The salt is random and
+// never stored, so pseudonyms cannot be traced back to the original values.
+func anonymize(ctx context.Context, log *logger.Logger, cfg *config.Config) error {
+	target := strings.TrimSpace(cfg.AnonymizeTarget)
+	if err := checkAnonymizeTarget(cfg, target); err != nil {
+		return err
+	}
+
+	var (
+		store *api_keys.SQLStore
+		err   error
+	)
+	if isPostgresURL(target) {
+		store, err = api_keys.NewExternalStore(ctx, log, target)
+	} else {
+		// Opening a missing SQLite file would create an empty database and report success.
+		if _, statErr := os.Stat(target); statErr != nil {
+			return fmt.Errorf("anonymize target %q: %w", target, statErr)
+		}
+		store, err = api_keys.NewSQLiteStore(ctx, log, target)
+	}
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	result, err := store.Anonymize(ctx, salt)
+	if err != nil {
+		return err
+	}
+	log.Info("Database anonymized",
+		"users", result.Users,
+		"keys", result.Keys,
+		"label_values", result.LabelValues,
+	)
+	return nil
+}
+
+// checkAnonymizeTarget refuses targets that are the database the server is configured to serve from,
+// so that --anonymize-target cannot be pointed at live data by reusing the serving configuration.
+func checkAnonymizeTarget(cfg *config.Config, target string) error {
+	if target == "" || target == ":memory:" {
+		return errors.New("--anonymize-target must name a database copy (postgresql:// URL or SQLite file path)")
+	}
+	if isPostgresURL(target) {
+		if serving := strings.TrimSpace(cfg.DBConnectionURL); serving != "" && samePostgresDatabase(target, serving) {
+			return errors.New("--anonymize-target is the serving database (--db-connection-url); anonymize a copy instead")
+		}
+		return nil
+	}
+	servingPath := strings.TrimSpace(cfg.DataPath)
+	if servingPath == "" {
+		servingPath = config.DefaultDataPath
+	}
+	if samePath(target, servingPath) {
+		return errors.New("--anonymize-target is the serving database (--data-path); anonymize a copy instead")
+	}
+	return nil
+}
+
+func isPostgresURL(target string) bool {
+	return strings.HasPrefix(target, "postgresql://") || strings.HasPrefix(target, "postgres://")
+}
+
+// samePostgresDatabase reports whether two connection URLs address the same host, port and database,
+// ignoring credentials and query parameters. Unparsable URLs are compared verbatim.
+func samePostgresDatabase(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return postgresHostPort(ua) == postgresHostPort(ub) && strings.Trim(ua.Path, "/") == strings.Trim(ub.Path, "/")
+}
+
+func postgresHostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "5432"
+	}
+	return net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+}
+
+// samePath reports whether a and b name the same file, following symlinks where the files exist.
+func samePath(a, b string) bool {
+	if ai, err := os.Stat(a); err == nil {
+		if bi, err := os.Stat(b); err == nil {
+			return os.SameFile(ai, bi)
+		}
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
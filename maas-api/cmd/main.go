@@ -9,20 +9,27 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	clientmetrics "k8s.io/client-go/tools/metrics"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
-	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/faults"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/ratelimit"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tier"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/webhook"
 )
 
 func main() {
@@ -35,17 +42,46 @@ func main() {
 		_ = appLogger.Sync() // Ignore sync errors on close, as per zap documentation
 	}()
 
+	if err := cfg.Validate(); err != nil {
+		appLogger.Fatal("Invalid configuration",
+			"error", err,
+		)
+	}
+
+	if cfg.AnonymizeTarget != "" {
+		if err := anonymize(context.Background(), appLogger, cfg); err != nil {
+			appLogger.Fatal("Failed to anonymize database",
+				"error", err,
+			)
+		}
+		return
+	}
+
 	gin.SetMode(gin.ReleaseMode) // Explicitly set release mode
 	if cfg.DebugMode {
 		gin.SetMode(gin.DebugMode)
 	}
 
+	registry := metrics.NewRegistry()
+	kubeClientMetrics, err := metrics.NewKubeClient(registry)
+	if err != nil {
+		appLogger.Fatal("Failed to register Kubernetes client metrics",
+			"error", err,
+		)
+	}
+	clientmetrics.Register(kubeClientMetrics.RegisterOpts())
+
 	router := gin.Default()
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		appLogger.Fatal("Invalid trusted proxies configuration",
+			"error", err,
+		)
+	}
 	if cfg.DebugMode {
 		router.Use(cors.New(cors.Config{
 			AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 			AllowHeaders:  []string{"Authorization", "Content-Type", "Accept"},
-			ExposeHeaders: []string{"Content-Type"},
+			ExposeHeaders: []string{"Content-Type", "Deprecation", "Sunset", "Link"},
 			AllowOriginFunc: func(origin string) bool {
 				return true
 			},
@@ -54,32 +90,39 @@ func main() {
 		}))
 	}
 
+	deprecatedRoutes, err := middleware.ParseDeprecatedRoutes(cfg.DeprecatedRoutes)
+	if err != nil {
+		appLogger.Fatal("Invalid deprecated routes configuration",
+			"error", err,
+		)
+	}
+	router.Use(middleware.Deprecation(deprecatedRoutes))
+
 	router.OPTIONS("/*path", func(c *gin.Context) { c.Status(204) })
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	store, err := initStore(ctx, appLogger, cfg)
-	if err != nil {
-		appLogger.Fatal("Failed to initialize token store",
-			"error", err,
+	const writeTimeout = 30 * time.Second
+	if cfg.RouteTimeout >= writeTimeout || cfg.TokenRouteTimeout >= writeTimeout {
+		appLogger.Warn("Route timeouts should be shorter than the server write timeout",
+			"route_timeout", cfg.RouteTimeout.String(),
+			"token_route_timeout", cfg.TokenRouteTimeout.String(),
+			"write_timeout", writeTimeout.String(),
 		)
 	}
-	defer func() {
-		if err := store.Close(); err != nil {
-			appLogger.Error("Failed to close token store",
-				"error", err,
-			)
-		}
-	}()
 
-	registerHandlers(ctx, appLogger, router, cfg, store)
+	// The server starts before initialization so that liveness and startup probes are answered
+	// while migrations and informer syncs run. Until then only the probe routes are served.
+	startup := handlers.NewStartupTracker()
+	handler := &switchHandler{}
+	handler.Store(bootstrapRouter(startup))
 
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
-		Handler:           router,
+		Handler:           handler,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       15 * time.Second,
-		WriteTimeout:      30 * time.Second,
+		WriteTimeout:      writeTimeout,
 		IdleTimeout:       60 * time.Second,
 		MaxHeaderBytes:    1 << 20,
 	}
@@ -96,6 +139,49 @@ func main() {
 		}
 	}()
 
+	var metricsSrv *http.Server
+	if cfg.MetricsPort != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics.Handler(registry))
+		metricsSrv = &http.Server{
+			Addr:              ":" + cfg.MetricsPort,
+			Handler:           metricsMux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		go func() {
+			appLogger.Info("Metrics server starting",
+				"port", cfg.MetricsPort,
+			)
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				appLogger.Fatal("Metrics server failed to start",
+					"error", err,
+				)
+			}
+		}()
+	}
+
+	startup.Phase("storage")
+	store, err := initStore(ctx, appLogger, cfg)
+	if err != nil {
+		appLogger.Fatal("Failed to initialize token store",
+			"error", err,
+		)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			appLogger.Error("Failed to close token store",
+				"error", err,
+			)
+		}
+	}()
+
+	registerHandlers(ctx, appLogger, router, cfg, store, startup)
+
+	router.GET("/health/startup", startup.Startup)
+	handler.Store(router)
+	startup.Done()
+	appLogger.Info("Initialization complete")
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -110,6 +196,13 @@ func main() {
 			"error", err,
 		)
 	}
+	if metricsSrv != nil {
+		if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
+			appLogger.Error("Metrics server forced to shutdown",
+				"error", err,
+			)
+		}
+	}
 
 	appLogger.Info("Server exited gracefully")
 }
@@ -143,31 +236,115 @@ func initStore(ctx context.Context, log *logger.Logger, cfg *config.Config) (api
 			return nil, errors.New("--db-connection-url is required when using --storage=external")
 		}
 		log.Info("Connecting to external database...")
-		return api_keys.NewExternalStore(ctx, log, dbURL)
+		// An unreachable database must not take down /health and /v1/models, so the
+		// server starts in degraded mode and connects once the database is back.
+		return api_keys.NewReconnectingStore(ctx, log, func(ctx context.Context) (api_keys.MetadataStore, error) {
+			store, err := api_keys.NewExternalStore(ctx, log, dbURL)
+			if err != nil {
+				return nil, err
+			}
+			return store, nil
+		}), nil
 
 	default:
 		return nil, fmt.Errorf("unknown storage mode: %q (valid modes: in-memory, disk, external)", cfg.StorageMode)
 	}
 }
 
-func registerHandlers(ctx context.Context, log *logger.Logger, router *gin.Engine, cfg *config.Config, store api_keys.MetadataStore) {
-	router.GET("/health", handlers.NewHealthHandler().HealthCheck)
+// switchHandler serves the most recently stored handler, allowing the bootstrap router
+// to be replaced by the fully initialized one without restarting the listener.
+type switchHandler struct {
+	atomic.Pointer[http.Handler]
+}
+
+func (s *switchHandler) Store(h http.Handler) {
+	s.Pointer.Store(&h)
+}
 
-	cluster, err := config.NewClusterConfig(cfg.Namespace, constant.DefaultResyncPeriod)
+func (s *switchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*s.Load()).ServeHTTP(w, r)
+}
+
+// bootstrapRouter answers the liveness and startup probes during initialization;
+// every other route returns 503.
+func bootstrapRouter(startup *handlers.StartupTracker) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.GET("/health", handlers.NewHealthHandler(nil).HealthCheck)
+	router.GET("/health/startup", startup.Startup)
+	router.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusServiceUnavailable, errcode.ServiceStarting.Response())
+	})
+	return router
+}
+
+func registerHandlers(ctx context.Context, log *logger.Logger, router *gin.Engine, cfg *config.Config, store api_keys.MetadataStore, startup *handlers.StartupTracker) {
+	startup.Phase("cluster")
+	cluster, err := config.NewClusterConfig(cfg.Namespace, config.InformerOptions{
+		ResyncPeriod:       cfg.InformerResyncPeriod,
+		ModelNamespaces:    cfg.ModelNamespaces,
+		ModelLabelSelector: cfg.ModelLabelSelector,
+		TokenLabelSelector: cfg.TokenLabelSelector,
+	})
 	if err != nil {
 		log.Fatal("Failed to create cluster config",
 			"error", err,
 		)
 	}
 
+	// With a reconnecting store the server keeps serving models while the database is down,
+	// so the database must not gate readiness; its routes answer 503 instead.
+	storageConnected := func() bool { return true }
+	reconnecting, degradable := store.(*api_keys.ReconnectingStore)
+	if degradable {
+		storageConnected = reconnecting.Connected
+	}
+
+	if cfg.FaultInjection {
+		if !cfg.DebugMode {
+			log.Fatal("Fault injection is only available in debug mode")
+		}
+		defaultFaults, err := faults.Parse(cfg.FaultSpec)
+		if err != nil {
+			log.Fatal("Invalid fault spec",
+				"error", err,
+			)
+		}
+		log.Warn("Fault injection enabled",
+			"fault_spec", cfg.FaultSpec,
+		)
+		store = api_keys.NewFaultyStore(store)
+		router.Use(middleware.InjectFaults(defaultFaults))
+	}
+
+	healthHandler := handlers.NewHealthHandler(log,
+		handlers.DependencyCheck{Name: "database", Hard: !degradable, Probe: store.Ping},
+		// Models are served from informer caches, so an API server blip only degrades token issuance.
+		handlers.DependencyCheck{Name: "kubernetes", Hard: false, Probe: cluster.Ping},
+	)
+	router.GET("/health", healthHandler.HealthCheck)
+	router.GET("/health/ready", healthHandler.Readiness)
+
+	startup.Phase("informer-sync")
 	if !cluster.StartAndWaitForSync(ctx.Done()) {
 		log.Fatal("Failed to sync informer caches")
 	}
 
+	startup.Phase("routes")
 	v1Routes := router.Group("/v1")
 
 	tierMapper := tier.NewMapper(log, cluster.ConfigMapLister, cfg.Name, cfg.Namespace)
-	v1Routes.POST("/tiers/lookup", tier.NewHandler(tierMapper).TierLookup)
+	tierNotifier := tier.NewNotifier(log, tierMapper, cluster.ClientSet, cluster.NamespaceLister)
+	if err := cluster.AddConfigMapEventHandler(tierNotifier.EventHandler()); err != nil {
+		log.Fatal("Failed to watch tier configuration",
+			"error", err,
+		)
+	}
+	go tierNotifier.Run(ctx)
+	tierHandler := tier.NewHandler(log, tierMapper, tierNotifier)
+	v1Routes.POST("/tiers/lookup", middleware.Timeout(cfg.RouteTimeout), tierHandler.TierLookup)
+	v1Routes.GET("/tiers/:name", middleware.Timeout(cfg.RouteTimeout), tierHandler.GetTier)
+	v1Routes.POST("/tools/ratelimit-spec", middleware.Timeout(cfg.RouteTimeout), tierHandler.RateLimitSpec)
 
 	modelMgr, errMgr := models.NewManager(
 		log,
@@ -192,22 +369,50 @@ func registerHandlers(ctx context.Context, log *logger.Logger, router *gin.Engin
 		cluster.ClientSet,
 		cluster.NamespaceLister,
 		cluster.ServiceAccountLister,
+		store,
 	)
 	tokenHandler := token.NewHandler(log, cfg.Name, tokenManager)
 
-	apiKeyService := api_keys.NewService(tokenManager, store)
+	var keyEvents api_keys.EventSink
+	var sender *webhook.Sender
+	if cfg.WebhookURL != "" {
+		if cfg.WebhookSecret == "" {
+			log.Fatal("WEBHOOK_SECRET is required when a webhook URL is configured")
+		}
+		sender = webhook.NewSender(log, cfg.WebhookURL, cfg.WebhookSecret)
+		go sender.Run(ctx)
+		keyEvents = sender
+	}
+
+	if cfg.ExpiryReminderWindow > 0 {
+		if sender == nil {
+			log.Fatal("Expiry reminders are sent by webhook; set WEBHOOK_URL or disable EXPIRY_REMINDER_WINDOW")
+		}
+		if cfg.ExpiryReminderInterval <= 0 {
+			log.Fatal("EXPIRY_REMINDER_INTERVAL must be positive")
+		}
+		reminder := api_keys.NewExpiryReminder(log, store, sender, cfg.ExpiryReminderWindow, cfg.ExpiryReminderInterval)
+		go reminder.Run(ctx)
+	}
+
+	apiKeyService := api_keys.NewService(tokenManager, store, cfg.MaxKeysPerUser, cfg.IdempotencyWindow, keyEvents)
 	apiKeyHandler := api_keys.NewHandler(log, apiKeyService)
 
 	// Model listing endpoint (v1Routes is grouped under /v1, so this creates /v1/models)
-	v1Routes.GET("/models", tokenHandler.ExtractUserInfo(), modelsHandler.ListLLMs)
+	v1Routes.GET("/models", middleware.Timeout(cfg.RouteTimeout), tokenHandler.ExtractUserInfo(), modelsHandler.ListLLMs)
+
+	issuanceLimiter := ratelimit.NewIssuance(cfg.TokenIssueRatePerMinute, cfg.TokenIssueBurst, cfg.RevokeCooldown)
 
-	tokenRoutes := v1Routes.Group("/tokens", tokenHandler.ExtractUserInfo())
-	tokenRoutes.POST("", tokenHandler.IssueToken)
-	tokenRoutes.DELETE("", apiKeyHandler.RevokeAllTokens)
+	tokenRoutes := v1Routes.Group("/tokens", middleware.Timeout(cfg.TokenRouteTimeout), tokenHandler.ExtractUserInfo())
+	tokenRoutes.POST("", issuanceLimiter.Limit(), tokenHandler.IssueToken)
+	tokenRoutes.DELETE("", middleware.RequireStorage(storageConnected), issuanceLimiter.CooldownOnRevoke(), apiKeyHandler.RevokeAllTokens)
+	tokenRoutes.GET("/audit", middleware.RequireStorage(storageConnected), apiKeyHandler.ListTokenAudit)
 
-	apiKeyRoutes := v1Routes.Group("/api-keys", tokenHandler.ExtractUserInfo())
-	apiKeyRoutes.POST("", apiKeyHandler.CreateAPIKey)
+	apiKeyRoutes := v1Routes.Group("/api-keys", middleware.Timeout(cfg.TokenRouteTimeout),
+		middleware.RequireStorage(storageConnected), tokenHandler.ExtractUserInfo())
+	apiKeyRoutes.POST("", issuanceLimiter.Limit(), apiKeyHandler.CreateAPIKey)
 	apiKeyRoutes.GET("", apiKeyHandler.ListAPIKeys)
 	apiKeyRoutes.GET("/:id", apiKeyHandler.GetAPIKey)
+	apiKeyRoutes.PATCH("/:id", apiKeyHandler.UpdateAPIKey)
 	// Note: Single key deletion removed for initial release - use DELETE /v1/tokens to revoke all tokens
 }
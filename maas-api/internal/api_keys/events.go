@@ -0,0 +1,31 @@
+package api_keys
+
+import "github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+
+// API key lifecycle event types.
+const (
+	EventKeyCreated = "api_key.created"
+	EventKeyRevoked = "api_key.revoked"
+)
+
+// EventSink receives API key lifecycle events, e.g. to forward them to a webhook.
+// Send must not block.
+type EventSink interface {
+	Send(eventType string, data any)
+}
+
+// LifecycleEvent describes the API key an event refers to. It never contains the key itself.
+type LifecycleEvent struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Username  string            `json:"username"`
+	ExpiresAt types.Timestamp   `json:"expiresAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+func (s *Service) emit(eventType string, event LifecycleEvent) {
+	if s.events == nil {
+		return
+	}
+	s.events.Send(eventType, event)
+}
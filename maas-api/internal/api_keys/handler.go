@@ -1,11 +1,19 @@
 package api_keys
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/fields"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
 )
@@ -26,29 +34,66 @@ func NewHandler(log *logger.Logger, service *Service) *Handler {
 }
 
 type CreateRequest struct {
-	Name        string          `json:"name"`
-	Description string          `json:"description,omitempty"`
-	Expiration  *token.Duration `json:"expiration"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Expiration  *token.Duration   `json:"expiration"`
+}
+
+// UpdateRequest is a JSON merge patch for an API key. Only labels can be changed;
+// a null label value removes the label.
+type UpdateRequest struct {
+	Labels map[string]*string `json:"labels"`
+}
+
+const (
+	// IdempotencyKeyHeader lets clients retry POST /v1/api-keys without minting duplicate keys.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed for a previously seen Idempotency-Key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// Response is returned on API key creation. Token is omitted on idempotent replays
+// because key secrets are never stored.
+// ReplayedKey identifies the key created by the original request of an idempotent replay.
+type ReplayedKey struct {
+	JTI       string `json:"jti"`
+	Name      string `json:"name"`
+	ExpiresAt int64  `json:"expiresAt"`
 }
 
 type Response struct {
-	Token       string `json:"token"`
-	Expiration  string `json:"expiration"`
-	ExpiresAt   int64  `json:"expiresAt"`
-	JTI         string `json:"jti"`
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Token       string            `json:"token"`
+	Expiration  string            `json:"expiration"`
+	ExpiresAt   int64             `json:"expiresAt"`
+	JTI         string            `json:"jti"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// Audiences and MaxExpiration reflect the caller's tier token policy.
+	Audiences     []string        `json:"audiences,omitempty"`
+	MaxExpiration *token.Duration `json:"maxExpiration,omitempty"`
 }
 
 func (h *Handler) CreateAPIKey(c *gin.Context) {
 	var req CreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail(err.Error()))
 		return
 	}
 
 	if req.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "token name is required for api keys"})
+		c.JSON(http.StatusBadRequest, errcode.APIKeyNameRequired.Response())
+		return
+	}
+
+	if err := validateLabels(req.Labels); err != nil {
+		c.JSON(http.StatusBadRequest, errcode.APIKeyLabelsInvalid.ResponseWithDetail(err.Error()))
 		return
 	}
 
@@ -58,100 +103,316 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 
 	userCtx, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
 		return
 	}
 
 	user, ok := userCtx.(*token.UserContext)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
 		return
 	}
 
 	expiration := req.Expiration.Duration
 	if err := token.ValidateExpiration(expiration, 10*time.Minute); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, errcode.InvalidExpiration.ResponseWithDetail(err.Error()))
 		return
 	}
 
-	tok, err := h.service.CreateAPIKey(c.Request.Context(), user, req.Name, req.Description, expiration)
+	idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+	if idempotencyKey != "" {
+		h.createAPIKeyIdempotent(c, user, idempotencyKey, req.Name, req.Description, req.Labels, expiration)
+		return
+	}
+
+	tok, err := h.service.CreateAPIKey(c.Request.Context(), user, req.Name, req.Description, req.Labels, expiration)
 	if err != nil {
-		h.logger.Error("Failed to generate API key",
-			"error", err,
-		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate api key"})
+		h.respondCreateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newResponse(tok))
+}
+
+func (h *Handler) createAPIKeyIdempotent(c *gin.Context, user *token.UserContext, idempotencyKey, name, description string,
+	labels map[string]string, expiration time.Duration,
+) {
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		c.JSON(http.StatusBadRequest, errcode.IdempotencyKeyInvalid.Response())
+		return
+	}
+
+	tok, replayed, err := h.service.CreateAPIKeyIdempotent(c.Request.Context(), user, idempotencyKey,
+		requestHash(name, description, labels, expiration), name, description, labels, expiration)
+	switch {
+	case errors.Is(err, ErrIdempotencyKeyReused):
+		c.JSON(http.StatusUnprocessableEntity, errcode.IdempotencyKeyReused.Response())
+		return
+	case errors.Is(err, ErrIdempotencyKeyInProgress):
+		c.JSON(http.StatusConflict, errcode.IdempotencyKeyInProgress.Response())
+		return
+	case err != nil:
+		h.respondCreateError(c, err)
+		return
+	}
+
+	if replayed != nil {
+		// The token is never stored, so the original response cannot be replayed. A non-2xx status
+		// keeps clients from mistaking the keyless body for a created key.
+		c.Header(IdempotentReplayedHeader, "true")
+		response := errcode.IdempotencyKeyReplayed.Response()
+		response["key"] = ReplayedKey{
+			JTI:       replayed.ID,
+			Name:      replayed.Name,
+			ExpiresAt: replayed.ExpirationDate.Unix(),
+		}
+		c.JSON(http.StatusConflict, response)
 		return
 	}
 
-	c.JSON(http.StatusCreated, Response{
-		Token:       tok.Token.Token,
-		Expiration:  tok.Expiration.String(),
-		ExpiresAt:   tok.ExpiresAt,
-		JTI:         tok.JTI,
-		Name:        tok.Name,
-		Description: tok.Description,
-	})
+	c.JSON(http.StatusCreated, newResponse(tok))
 }
 
+func (h *Handler) respondCreateError(c *gin.Context, err error) {
+	if errors.Is(err, ErrKeyQuotaExceeded) {
+		c.JSON(http.StatusConflict, errcode.APIKeyQuotaExceeded.Response())
+		return
+	}
+	var tierErr *token.ExpirationExceedsTierError
+	if errors.As(err, &tierErr) {
+		c.JSON(http.StatusBadRequest, errcode.InvalidExpiration.ResponseWithDetail(tierErr.Error()))
+		return
+	}
+	h.logger.Error("Failed to generate API key",
+		"error", err,
+	)
+	c.JSON(errcode.APIKeyCreateFailed.Failure(err))
+}
+
+func newResponse(tok *APIKey) Response {
+	return Response{
+		Token:         tok.Token.Token,
+		Expiration:    tok.Expiration.String(),
+		ExpiresAt:     tok.ExpiresAt,
+		JTI:           tok.JTI,
+		Name:          tok.Name,
+		Description:   tok.Description,
+		Labels:        tok.Labels,
+		Audiences:     tok.Audiences,
+		MaxExpiration: tok.MaxExpiration,
+	}
+}
+
+// requestHash fingerprints the validated creation request, so that an idempotency key
+// reused with different parameters is rejected rather than replayed.
+func requestHash(name, description string, labels map[string]string, expiration time.Duration) string {
+	// Maps are marshaled with sorted keys, so equal label sets hash equally.
+	payload, _ := json.Marshal(struct {
+		Name        string            `json:"name"`
+		Description string            `json:"description"`
+		Labels      map[string]string `json:"labels,omitempty"`
+		Expiration  int64             `json:"expiration"`
+	}{name, description, labels, int64(expiration / time.Second)})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// ListAPIKeys handles GET /v1/api-keys[?label=key=value...][&name=][&sort=][&limit=][&offset=].
+// Repeated label filters must all match. The body is the requested page, DefaultListLimit keys unless
+// ?limit= says otherwise; X-Total-Count holds the number of keys matching the filters across all pages.
 func (h *Handler) ListAPIKeys(c *gin.Context) {
+	opts, err := parseListQuery(c)
+	if err != nil {
+		if errors.Is(err, ErrInvalidLabels) {
+			c.JSON(http.StatusBadRequest, errcode.APIKeyLabelsInvalid.ResponseWithDetail(err.Error()))
+			return
+		}
+		c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail(err.Error()))
+		return
+	}
+
 	userCtx, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
 		return
 	}
 
 	user, ok := userCtx.(*token.UserContext)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
 		return
 	}
 
-	tokens, err := h.service.ListAPIKeys(c.Request.Context(), user)
+	tokens, total, err := h.service.ListAPIKeys(c.Request.Context(), user, opts)
 	if err != nil {
 		h.logger.Error("Failed to list API keys",
 			"error", err,
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list api keys"})
+		c.JSON(errcode.APIKeyListFailed.Failure(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, tokens)
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	h.respondWithFields(c, tokens)
 }
 
 func (h *Handler) GetAPIKey(c *gin.Context) {
 	tokenID := c.Param("id")
 	if tokenID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Token ID required"})
+		c.JSON(http.StatusBadRequest, errcode.APIKeyIDRequired.Response())
+		return
+	}
+
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
 		return
 	}
 
-	tok, err := h.service.GetAPIKey(c.Request.Context(), tokenID)
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
+		return
+	}
+
+	tok, err := h.service.GetAPIKey(c.Request.Context(), user, tokenID)
 	if err != nil {
 		if errors.Is(err, ErrTokenNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			c.JSON(http.StatusNotFound, errcode.APIKeyNotFound.Response())
 			return
 		}
 		h.logger.Error("Failed to get API key",
 			"error", err,
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API key"})
+		c.JSON(errcode.APIKeyGetFailed.Failure(err))
+		return
+	}
+
+	h.respondWithFields(c, tok)
+}
+
+// UpdateAPIKey handles PATCH /v1/api-keys/:id with a JSON merge patch of the key's labels.
+func (h *Handler) UpdateAPIKey(c *gin.Context) {
+	tokenID := c.Param("id")
+	if tokenID == "" {
+		c.JSON(http.StatusBadRequest, errcode.APIKeyIDRequired.Response())
+		return
+	}
+
+	var req UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail(err.Error()))
+		return
+	}
+	if req.Labels == nil {
+		c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail("labels is required"))
+		return
+	}
+
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
+		return
+	}
+
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
+		return
+	}
+
+	tok, err := h.service.UpdateLabels(c.Request.Context(), user, tokenID, req.Labels)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTokenNotFound):
+			c.JSON(http.StatusNotFound, errcode.APIKeyNotFound.Response())
+		case errors.Is(err, ErrInvalidLabels):
+			c.JSON(http.StatusBadRequest, errcode.APIKeyLabelsInvalid.ResponseWithDetail(err.Error()))
+		default:
+			h.logger.Error("Failed to update API key",
+				"error", err,
+			)
+			c.JSON(errcode.APIKeyUpdateFailed.Failure(err))
+		}
+		return
+	}
+
+	h.respondWithFields(c, tok)
+}
+
+// respondWithFields writes v as 200 OK, reduced to the fields selected via ?fields= if any.
+func (h *Handler) respondWithFields(c *gin.Context, v any) {
+	projected, err := fields.Project(v, fields.FromQuery(c))
+	if err != nil {
+		h.logger.Error("Failed to project response fields",
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, errcode.ResponseFailed.Response())
+		return
+	}
+
+	c.JSON(http.StatusOK, projected)
+}
+
+// ListTokenAudit handles GET /v1/tokens/audit?namespace=<ns>[&user=<username>][&limit=<n>].
+func (h *Handler) ListTokenAudit(c *gin.Context) {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail("namespace query parameter is required"))
+		return
+	}
+
+	limit := defaultAuditLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAuditLimit {
+			c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail(
+				fmt.Sprintf("limit must be an integer between 1 and %d", maxAuditLimit)))
+			return
+		}
+		limit = parsed
+	}
+
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
+		return
+	}
+
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
+		return
+	}
+
+	events, err := h.service.ListTokenAudit(c.Request.Context(), user, namespace, c.Query("user"), limit)
+	if err != nil {
+		if errors.Is(err, ErrAuditForbidden) {
+			c.JSON(http.StatusForbidden, errcode.TokenAuditForbidden.Response())
+			return
+		}
+		h.logger.Error("Failed to list token audit",
+			"namespace", namespace,
+			"error", err,
+		)
+		c.JSON(errcode.TokenAuditFailed.Failure(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, tok)
+	h.respondWithFields(c, events)
 }
 
 // RevokeAllTokens handles DELETE /v1/tokens.
 func (h *Handler) RevokeAllTokens(c *gin.Context) {
 	userCtx, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
 		return
 	}
 
 	user, ok := userCtx.(*token.UserContext)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
 		return
 	}
 
@@ -159,7 +420,7 @@ func (h *Handler) RevokeAllTokens(c *gin.Context) {
 		h.logger.Error("Failed to revoke tokens",
 			"error", err,
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke tokens"})
+		c.JSON(errcode.TokenRevokeFailed.Failure(err))
 		return
 	}
 
@@ -0,0 +1,121 @@
+package api_keys_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/test/fixtures"
+)
+
+func TestHandlerListAPIKeysPaging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := t.Context()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	const keyCount = api_keys.DefaultListLimit + 20
+	for i := range keyCount {
+		require.NoError(t, store.Add(ctx, "many-keys", &api_keys.APIKey{
+			Token: token.Token{JTI: fmt.Sprintf("jti-%03d", i), ExpiresAt: time.Now().Add(time.Hour).Unix()},
+			Name:  fmt.Sprintf("key-%03d", i),
+		}))
+	}
+
+	handler := api_keys.NewHandler(logger.Development(), api_keys.NewService(nil, store, 0, 0, nil))
+	router := gin.New()
+	router.GET("/v1/api-keys", func(c *gin.Context) {
+		c.Set("user", &token.UserContext{Username: "many-keys", Groups: []string{"system:authenticated"}})
+	}, handler.ListAPIKeys)
+
+	list := func(t *testing.T, query string) ([]api_keys.ApiKeyMetadata, int) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/v1/api-keys"+query, nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var keys []api_keys.ApiKeyMetadata
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &keys))
+		total, err := strconv.Atoi(w.Header().Get("X-Total-Count"))
+		require.NoError(t, err)
+		return keys, total
+	}
+
+	t.Run("UnparameterisedListIsBounded", func(t *testing.T) {
+		keys, total := list(t, "")
+		assert.Len(t, keys, api_keys.DefaultListLimit)
+		assert.Equal(t, keyCount, total)
+	})
+
+	t.Run("OffsetReachesRemainingKeys", func(t *testing.T) {
+		keys, total := list(t, "?offset="+strconv.Itoa(api_keys.DefaultListLimit))
+		assert.Len(t, keys, keyCount-api_keys.DefaultListLimit)
+		assert.Equal(t, keyCount, total)
+	})
+
+	t.Run("ExplicitLimit", func(t *testing.T) {
+		keys, _ := list(t, "?limit=1000")
+		assert.Len(t, keys, keyCount)
+	})
+}
+
+func TestHandlerCreateAPIKeyIdempotentReplay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	handler := api_keys.NewHandler(logger.Development(), api_keys.NewService(manager, store, 0, time.Hour, nil))
+	router := gin.New()
+	router.POST("/v1/api-keys", func(c *gin.Context) {
+		c.Set("user", &token.UserContext{Username: "retry-user", Groups: []string{"system:authenticated"}})
+	}, handler.CreateAPIKey)
+
+	create := func(t *testing.T) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/api-keys",
+			strings.NewReader(`{"name": "ci", "expiration": "1h"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(api_keys.IdempotencyKeyHeader, "retry-1")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := create(t)
+	require.Equal(t, http.StatusCreated, first.Code)
+	var created api_keys.Response
+	require.NoError(t, json.Unmarshal(first.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Token)
+
+	replay := create(t)
+	assert.Equal(t, http.StatusConflict, replay.Code, "a keyless replay must not look like success")
+	assert.Equal(t, "true", replay.Header().Get(api_keys.IdempotentReplayedHeader))
+
+	var body struct {
+		Code string               `json:"code"`
+		Key  api_keys.ReplayedKey `json:"key"`
+	}
+	require.NoError(t, json.Unmarshal(replay.Body.Bytes(), &body))
+	assert.Equal(t, string(errcode.IdempotencyKeyReplayed), body.Code)
+	assert.Equal(t, created.JTI, body.Key.JTI)
+	assert.NotContains(t, replay.Body.String(), created.Token)
+}
@@ -0,0 +1,68 @@
+package api_keys
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MaxLabelsPerKey caps the number of labels a single API key may carry.
+const MaxLabelsPerKey = 32
+
+// ErrInvalidLabels is returned when API key labels are malformed or too many.
+var ErrInvalidLabels = errors.New("invalid labels")
+
+// validateLabels checks labels against Kubernetes label syntax, so keys can be
+// organized with the same conventions platform teams use for cluster resources.
+func validateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabelsPerKey {
+		return fmt.Errorf("%w: at most %d labels are allowed, got %d", ErrInvalidLabels, MaxLabelsPerKey, len(labels))
+	}
+	for key, value := range labels {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			return fmt.Errorf("%w: key %q: %s", ErrInvalidLabels, key, strings.Join(msgs, "; "))
+		}
+		if msgs := validation.IsValidLabelValue(value); len(msgs) > 0 {
+			return fmt.Errorf("%w: value %q of %q: %s", ErrInvalidLabels, value, key, strings.Join(msgs, "; "))
+		}
+	}
+	return nil
+}
+
+// parseLabelSelector parses repeated ?label=key=value query parameters into
+// equality requirements that must all match.
+func parseLabelSelector(params []string) (map[string]string, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	selector := make(map[string]string, len(params))
+	for _, param := range params {
+		key, value, found := strings.Cut(param, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("%w: label filter %q must have the form key=value", ErrInvalidLabels, param)
+		}
+		if existing, ok := selector[key]; ok && existing != value {
+			return nil, fmt.Errorf("%w: label %q is filtered on more than one value", ErrInvalidLabels, key)
+		}
+		selector[key] = value
+	}
+	return selector, nil
+}
+
+// mergeLabels applies a JSON merge patch to labels: null values remove the label.
+func mergeLabels(labels map[string]string, patch map[string]*string) map[string]string {
+	merged := make(map[string]string, len(labels)+len(patch))
+	for key, value := range labels {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = *value
+	}
+	return merged
+}
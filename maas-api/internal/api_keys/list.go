@@ -0,0 +1,100 @@
+package api_keys
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxListLimit is the largest page size accepted by GET /v1/api-keys.
+const MaxListLimit = 1000
+
+// DefaultListLimit is the page size of GET /v1/api-keys when ?limit= is omitted.
+const DefaultListLimit = 100
+
+// DefaultListSort orders keys newest first.
+const DefaultListSort = "-created"
+
+// ErrInvalidListOptions is returned for unknown sort fields or out of range paging parameters.
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+// sortColumns maps the sort fields accepted by the API to table columns.
+var sortColumns = map[string]string{
+	"name":    "name",
+	"created": "creation_date",
+	"expires": "expiration_date",
+}
+
+// ListOptions filters, sorts and pages API key listings. The zero value lists every key, newest first;
+// requests to GET /v1/api-keys are always paged, see parseListQuery.
+type ListOptions struct {
+	// Selector restricts the result to keys carrying all of the given labels.
+	Selector map[string]string
+	// Name restricts the result to keys whose name contains it, ignoring case.
+	Name string
+	// Sort is a field of sortColumns, prefixed with "-" for descending order. Empty means DefaultListSort.
+	Sort string
+	// Limit caps the number of keys returned; 0 means no limit.
+	Limit int
+	// Offset skips that many keys of the sorted result.
+	Offset int
+}
+
+// orderBy returns the ORDER BY clause for the sort option. The key ID breaks ties so that pages are stable.
+func (o ListOptions) orderBy() (string, error) {
+	sort := o.Sort
+	if sort == "" {
+		sort = DefaultListSort
+	}
+
+	direction := "ASC"
+	field := sort
+	if rest, ok := strings.CutPrefix(sort, "-"); ok {
+		direction, field = "DESC", rest
+	}
+
+	column, ok := sortColumns[field]
+	if !ok {
+		return "", fmt.Errorf("%w: sort must be one of name, created or expires, optionally prefixed with '-'", ErrInvalidListOptions)
+	}
+	return fmt.Sprintf("%s %s, id %s", column, direction, direction), nil
+}
+
+// parseListQuery reads ?label=, ?name=, ?sort=, ?limit= and ?offset= from the request.
+// Without ?limit= a page holds DefaultListLimit keys, so no request returns an unbounded list.
+func parseListQuery(c *gin.Context) (ListOptions, error) {
+	selector, err := parseLabelSelector(c.QueryArray("label"))
+	if err != nil {
+		return ListOptions{}, err
+	}
+
+	opts := ListOptions{
+		Selector: selector,
+		Name:     c.Query("name"),
+		Sort:     c.Query("sort"),
+		Limit:    DefaultListLimit,
+	}
+	if _, err := opts.orderBy(); err != nil {
+		return ListOptions{}, err
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxListLimit {
+			return ListOptions{}, fmt.Errorf("%w: limit must be an integer between 1 and %d", ErrInvalidListOptions, MaxListLimit)
+		}
+		opts.Limit = limit
+	}
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return ListOptions{}, fmt.Errorf("%w: offset must be a non-negative integer", ErrInvalidListOptions)
+		}
+		opts.Offset = offset
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,131 @@
+package api_keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+)
+
+// EventKeysExpiring is sent once per user with the keys that will expire within the reminder window.
+const EventKeysExpiring = "api_key.expiring"
+
+// ReminderOptOutLabel set to "false" on a key excludes it from expiry reminders. Keys carry no team,
+// so opting out is per key rather than per team.
+const ReminderOptOutLabel = "maas.opendatahub.io/expiry-reminders"
+
+// ExpiringKey is a key returned by MetadataStore.ClaimExpiring.
+type ExpiringKey struct {
+	ID        string
+	Username  string
+	Name      string
+	ExpiresAt types.Timestamp
+}
+
+// ExpiryDigest is the payload of EventKeysExpiring.
+type ExpiryDigest struct {
+	Username string           `json:"username"`
+	Keys     []LifecycleEvent `json:"keys"`
+}
+
+// EventDeliverer delivers an event synchronously and returns an error if the receiver did not accept it.
+type EventDeliverer interface {
+	Deliver(ctx context.Context, eventType string, data any) error
+}
+
+// ExpiryReminder periodically sends each user a digest of their keys expiring within the window,
+// so that keys are rotated before clients start failing. A key is recorded as reminded only once its
+// digest was delivered; failed digests are retried on the next run. Replicas never send the same
+// key concurrently, but a replica dying between delivery and recording it may repeat a reminder.
+type ExpiryReminder struct {
+	store    MetadataStore
+	events   EventDeliverer
+	window   time.Duration
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewExpiryReminder creates a reminder that checks every interval for keys expiring within window.
+func NewExpiryReminder(log *logger.Logger, store MetadataStore, events EventDeliverer, window, interval time.Duration) *ExpiryReminder {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &ExpiryReminder{
+		store:    store,
+		events:   events,
+		window:   window,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run sends digests every interval until ctx is cancelled.
+func (r *ExpiryReminder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RunOnce(ctx); err != nil {
+			r.logger.Warn("Failed to send key expiry reminders",
+				"error", err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce delivers a digest to every user with unreminded keys expiring within the window and returns
+// the number of digests delivered.
+func (r *ExpiryReminder) RunOnce(ctx context.Context) (int, error) {
+	keys, err := r.store.ClaimExpiring(ctx, time.Now().Add(r.window))
+
+	// Keys are ordered by user; claimed keys are sent even if claiming stopped part way.
+	var digests []ExpiryDigest
+	for _, key := range keys {
+		if len(digests) == 0 || digests[len(digests)-1].Username != key.Username {
+			digests = append(digests, ExpiryDigest{Username: key.Username})
+		}
+		digest := &digests[len(digests)-1]
+		digest.Keys = append(digest.Keys, LifecycleEvent{
+			ID:        key.ID,
+			Name:      key.Name,
+			Username:  key.Username,
+			ExpiresAt: key.ExpiresAt,
+		})
+	}
+
+	errs := []error{err}
+	sent := 0
+	for _, digest := range digests {
+		ids := make([]string, len(digest.Keys))
+		for i, key := range digest.Keys {
+			ids[i] = key.ID
+		}
+
+		// Claims are settled even if ctx is cancelled during delivery, so that they do not linger.
+		settleCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		if deliverErr := r.events.Deliver(ctx, EventKeysExpiring, digest); deliverErr != nil {
+			errs = append(errs, fmt.Errorf("failed to deliver reminder to %s: %w", digest.Username, deliverErr))
+			if err := r.store.ReleaseReminders(settleCtx, ids); err != nil {
+				errs = append(errs, err)
+			}
+			cancel()
+			continue
+		}
+		if err := r.store.CompleteReminders(settleCtx, ids); err != nil {
+			errs = append(errs, err)
+		}
+		cancel()
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package api_keys_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+func TestExpiryReminder(t *testing.T) {
+	ctx := t.Context()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	now := time.Now()
+	for _, key := range []struct {
+		user, jti string
+		expiresIn time.Duration
+		labels    map[string]string
+	}{
+		{"alice", "alice-soon", 2 * time.Hour, nil},
+		{"alice", "alice-later", 20 * time.Hour, nil},
+		{"alice", "alice-opted-out", time.Hour, map[string]string{api_keys.ReminderOptOutLabel: "false"}},
+		{"alice", "alice-next-month", 30 * 24 * time.Hour, nil},
+		{"bob", "bob-soon", time.Hour, nil},
+	} {
+		require.NoError(t, store.Add(ctx, key.user, &api_keys.APIKey{
+			Token:  token.Token{JTI: key.jti, ExpiresAt: now.Add(key.expiresIn).Unix()},
+			Name:   key.jti,
+			Labels: key.labels,
+		}))
+	}
+
+	sink := &recordingSink{}
+	reminder := api_keys.NewExpiryReminder(logger.Development(), store, sink, 24*time.Hour, time.Hour)
+
+	sent, err := reminder.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{api_keys.EventKeysExpiring, api_keys.EventKeysExpiring}, sink.events)
+
+	digests := map[string][]string{}
+	for _, payload := range sink.payloads {
+		digest, ok := payload.(api_keys.ExpiryDigest)
+		require.True(t, ok)
+		for _, key := range digest.Keys {
+			assert.Equal(t, digest.Username, key.Username)
+			digests[digest.Username] = append(digests[digest.Username], key.ID)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"alice": {"alice-soon", "alice-later"},
+		"bob":   {"bob-soon"},
+	}, digests)
+
+	t.Run("FailedDeliveriesAreRetried", func(t *testing.T) {
+		require.NoError(t, store.Add(ctx, "dave", &api_keys.APIKey{
+			Token: token.Token{JTI: "dave-soon", ExpiresAt: now.Add(time.Hour).Unix()},
+			Name:  "dave-soon",
+		}))
+
+		failing := &recordingSink{fail: errors.New("endpoint down")}
+		sent, err := api_keys.NewExpiryReminder(logger.Development(), store, failing, 24*time.Hour, time.Hour).RunOnce(ctx)
+		require.Error(t, err)
+		assert.Zero(t, sent)
+
+		sink := &recordingSink{}
+		sent, err = api_keys.NewExpiryReminder(logger.Development(), store, sink, 24*time.Hour, time.Hour).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, sink.payloads, 1)
+		assert.Equal(t, "dave", sink.payloads[0].(api_keys.ExpiryDigest).Username)
+	})
+
+	t.Run("KeysAreRemindedOnce", func(t *testing.T) {
+		sent, err := reminder.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("RevokedKeysAreSkipped", func(t *testing.T) {
+		require.NoError(t, store.Add(ctx, "carol", &api_keys.APIKey{
+			Token: token.Token{JTI: "carol-soon", ExpiresAt: now.Add(time.Hour).Unix()},
+			Name:  "carol-soon",
+		}))
+		require.NoError(t, store.InvalidateAll(ctx, "carol"))
+
+		sent, err := reminder.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
@@ -2,25 +2,74 @@ package api_keys
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+)
+
+var (
+	// ErrKeyQuotaExceeded is returned when a user already owns the maximum number of active API keys.
+	ErrKeyQuotaExceeded = errors.New("api key quota exceeded")
+	// ErrIdempotencyKeyReused is returned when an idempotency key is replayed with a different request body.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used with a different request")
+	// ErrIdempotencyKeyInProgress is returned when the original request for an idempotency key has not completed yet.
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
+	// ErrAuditForbidden is returned when the caller may not manage tokens in the requested namespace.
+	ErrAuditForbidden = errors.New("not allowed to view token audit for namespace")
 )
 
 type Service struct {
-	tokenManager *token.Manager
-	store        MetadataStore
+	tokenManager      *token.Manager
+	store             MetadataStore
+	maxKeysPerUser    int
+	idempotencyWindow time.Duration
+	events            EventSink
 }
 
-func NewService(tokenManager *token.Manager, store MetadataStore) *Service {
+// NewService creates the API key service.
+// maxKeysPerUser limits the number of active API keys per user; 0 means unlimited.
+// idempotencyWindow is how long an Idempotency-Key is remembered for replay.
+// events receives key lifecycle events and may be nil.
+func NewService(tokenManager *token.Manager, store MetadataStore, maxKeysPerUser int, idempotencyWindow time.Duration, events EventSink) *Service {
 	return &Service{
-		tokenManager: tokenManager,
-		store:        store,
+		tokenManager:      tokenManager,
+		store:             store,
+		maxKeysPerUser:    maxKeysPerUser,
+		idempotencyWindow: idempotencyWindow,
+		events:            events,
 	}
 }
 
-func (s *Service) CreateAPIKey(ctx context.Context, user *token.UserContext, name string, description string, expiration time.Duration) (*APIKey, error) {
+func (s *Service) CreateAPIKey(ctx context.Context, user *token.UserContext, name string, description string, labels map[string]string, expiration time.Duration) (*APIKey, error) {
+	return s.createAPIKey(ctx, user, "", name, description, labels, expiration)
+}
+
+// createAPIKey mints and stores a key. A non-empty idempotencyKey is completed in the same
+// transaction that stores the key.
+func (s *Service) createAPIKey(ctx context.Context, user *token.UserContext, idempotencyKey string,
+	name string, description string, labels map[string]string, expiration time.Duration,
+) (*APIKey, error) {
+	// Reserve a quota slot before minting so a rejected request leaves no token behind.
+	// The reservation is atomic, so concurrent requests cannot all pass the limit.
+	if s.maxKeysPerUser > 0 {
+		slot, err := s.store.ReserveKeySlot(ctx, user.Username, s.maxKeysPerUser)
+		if err != nil {
+			if errors.Is(err, ErrKeyQuotaExceeded) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to check api key quota: %w", err)
+		}
+		defer func() {
+			// Once the key is stored it counts itself. A slot that cannot be released expires on its own.
+			releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer cancel()
+			_ = s.store.ReleaseKeySlot(releaseCtx, slot)
+		}()
+	}
+
 	// Generate token
 	tok, err := s.tokenManager.GenerateToken(ctx, user, expiration, "")
 	if err != nil {
@@ -32,26 +81,142 @@ func (s *Service) CreateAPIKey(ctx context.Context, user *token.UserContext, nam
 		Token:       *tok,
 		Name:        name,
 		Description: description,
+		Labels:      labels,
 	}
 
-	if err := s.store.Add(ctx, user.Username, apiKey); err != nil {
+	// The token exists from here on, so record it even if the client has gone away.
+	persistCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if idempotencyKey != "" {
+		err = s.store.AddIdempotent(persistCtx, user.Username, apiKey, idempotencyKey)
+	} else {
+		err = s.store.Add(persistCtx, user.Username, apiKey)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to persist api key metadata: %w", err)
 	}
 
+	s.emit(EventKeyCreated, LifecycleEvent{
+		ID:        apiKey.JTI,
+		Name:      apiKey.Name,
+		Username:  user.Username,
+		ExpiresAt: types.NewTimestamp(time.Unix(apiKey.ExpiresAt, 0)),
+		Labels:    apiKey.Labels,
+	})
+
 	return apiKey, nil
 }
 
-func (s *Service) ListAPIKeys(ctx context.Context, user *token.UserContext) ([]ApiKeyMetadata, error) {
-	return s.store.List(ctx, user.Username)
+// CreateAPIKeyIdempotent creates an API key at most once per idempotency key within the replay window.
+// On a replay it returns the metadata of the key created by the original request instead of minting
+// a new one; the token itself is never stored and therefore cannot be returned again.
+func (s *Service) CreateAPIKeyIdempotent(ctx context.Context, user *token.UserContext, idempotencyKey, requestHash string,
+	name string, description string, labels map[string]string, expiration time.Duration,
+) (*APIKey, *ApiKeyMetadata, error) {
+	notBefore := time.Now().Add(-s.idempotencyWindow)
+	existing, err := s.store.ReserveIdempotencyKey(ctx, user.Username, idempotencyKey, requestHash, notBefore)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if existing != nil {
+		if existing.RequestHash != requestHash {
+			return nil, nil, ErrIdempotencyKeyReused
+		}
+		if existing.JTI == "" {
+			return nil, nil, ErrIdempotencyKeyInProgress
+		}
+		replayed, err := s.store.Get(ctx, user.Username, existing.JTI)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load api key for idempotent replay: %w", err)
+		}
+		return nil, replayed, nil
+	}
+
+	// The reservation is completed in the transaction that stores the key, so a stored key is
+	// always replayed and never minted twice.
+	apiKey, err := s.createAPIKey(ctx, user, idempotencyKey, name, description, labels, expiration)
+	if err != nil {
+		// Release the reservation so the client can retry with the same key.
+		// Use a fresh context: the request context may be the reason creation failed.
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if releaseErr := s.store.ReleaseIdempotencyKey(releaseCtx, user.Username, idempotencyKey); releaseErr != nil {
+			return nil, nil, errors.Join(err, releaseErr)
+		}
+		return nil, nil, err
+	}
+
+	return apiKey, nil, nil
+}
+
+// ListAPIKeys returns a page of the caller's API keys and the number of keys matching opts across all pages.
+func (s *Service) ListAPIKeys(ctx context.Context, user *token.UserContext, opts ListOptions) ([]ApiKeyMetadata, int, error) {
+	return s.store.List(ctx, user.Username, opts)
 }
 
-func (s *Service) GetAPIKey(ctx context.Context, id string) (*ApiKeyMetadata, error) {
-	return s.store.Get(ctx, id)
+// UpdateLabels applies a JSON merge patch to the labels of the caller's API key:
+// a null value removes the label. It returns the updated key.
+func (s *Service) UpdateLabels(ctx context.Context, user *token.UserContext, id string, patch map[string]*string) (*ApiKeyMetadata, error) {
+	current, err := s.store.Get(ctx, user.Username, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLabels(mergeLabels(current.Labels, patch)); err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]string, len(patch))
+	var remove []string
+	for key, value := range patch {
+		if value == nil {
+			remove = append(remove, key)
+			continue
+		}
+		set[key] = *value
+	}
+
+	if err := s.store.UpdateLabels(ctx, user.Username, id, set, remove); err != nil {
+		return nil, err
+	}
+
+	return s.store.Get(ctx, user.Username, id)
+}
+
+// GetAPIKey returns the caller's API key metadata. Keys of other users are not found.
+func (s *Service) GetAPIKey(ctx context.Context, user *token.UserContext, id string) (*ApiKeyMetadata, error) {
+	return s.store.Get(ctx, user.Username, id)
+}
+
+// ListTokenAudit returns the issuance and revocation history for a namespace.
+// Only users allowed to revoke tokens in the namespace (namespace admins) may read it.
+func (s *Service) ListTokenAudit(ctx context.Context, user *token.UserContext, namespace, username string, limit int) ([]token.AuditEvent, error) {
+	allowed, err := s.tokenManager.CanManageTokens(ctx, user, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrAuditForbidden
+	}
+	return s.store.ListAudit(ctx, namespace, username, limit)
 }
 
 // RevokeAll invalidates all tokens for the user (ephemeral and persistent).
 // It recreates the Service Account (invalidating all tokens) and marks API key metadata as expired.
 func (s *Service) RevokeAll(ctx context.Context, user *token.UserContext) error {
+	// Collect the keys being revoked first so that each revocation can be reported. Events are
+	// best-effort: if the keys cannot be listed, revocation still proceeds without them.
+	var revoked []ApiKeyMetadata
+	if s.events != nil {
+		if keys, _, err := s.store.List(ctx, user.Username, ListOptions{}); err == nil {
+			for _, key := range keys {
+				if key.Status == TokenStatusActive {
+					revoked = append(revoked, key)
+				}
+			}
+		}
+	}
+
 	// Revoke in K8s (recreate SA) - this invalidates all tokens
 	if err := s.tokenManager.RevokeTokens(ctx, user); err != nil {
 		return fmt.Errorf("failed to revoke tokens in k8s: %w", err)
@@ -62,5 +227,15 @@ func (s *Service) RevokeAll(ctx context.Context, user *token.UserContext) error
 		return fmt.Errorf("tokens revoked but failed to mark metadata as expired: %w", err)
 	}
 
+	for _, key := range revoked {
+		s.emit(EventKeyRevoked, LifecycleEvent{
+			ID:        key.ID,
+			Name:      key.Name,
+			Username:  user.Username,
+			ExpiresAt: key.ExpirationDate,
+			Labels:    key.Labels,
+		})
+	}
+
 	return nil
 }
@@ -0,0 +1,293 @@
+package api_keys_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/test/fixtures"
+)
+
+func TestServiceKeyQuota(t *testing.T) {
+	ctx := t.Context()
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	service := api_keys.NewService(manager, store, 2, 0, nil)
+	user := &token.UserContext{Username: "quota-user", Groups: []string{"system:authenticated"}}
+
+	for _, name := range []string{"key-1", "key-2"} {
+		_, err := service.CreateAPIKey(ctx, user, name, "", nil, time.Hour)
+		require.NoError(t, err)
+	}
+
+	_, err := service.CreateAPIKey(ctx, user, "key-3", "", nil, time.Hour)
+	require.ErrorIs(t, err, api_keys.ErrKeyQuotaExceeded)
+
+	keys, _, err := store.List(ctx, user.Username, api_keys.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, keys, 2, "rejected key must not be persisted")
+
+	t.Run("OtherUsersUnaffected", func(t *testing.T) {
+		other := &token.UserContext{Username: "other-user", Groups: []string{"system:authenticated"}}
+		_, err := service.CreateAPIKey(ctx, other, "key-1", "", nil, time.Hour)
+		require.NoError(t, err)
+	})
+
+	t.Run("ExpiredKeysDoNotCount", func(t *testing.T) {
+		require.NoError(t, store.InvalidateAll(ctx, user.Username))
+
+		_, err := service.CreateAPIKey(ctx, user, "key-4", "", nil, time.Hour)
+		require.NoError(t, err)
+	})
+}
+
+func TestServiceKeyQuotaConcurrent(t *testing.T) {
+	ctx := t.Context()
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	const limit = 3
+	service := api_keys.NewService(manager, store, limit, 0, nil)
+	user := &token.UserContext{Username: "race-user", Groups: []string{"system:authenticated"}}
+
+	// Create the service account up front so that concurrent requests only race on the quota.
+	_, err := service.CreateAPIKey(ctx, user, "key-0", "", nil, time.Hour)
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+		rejected  atomic.Int32
+	)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateAPIKey(ctx, user, fmt.Sprintf("key-%d", i+1), "", nil, time.Hour)
+			switch {
+			case err == nil:
+				succeeded.Add(1)
+			case errors.Is(err, api_keys.ErrKeyQuotaExceeded):
+				rejected.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(limit-1), succeeded.Load())
+	assert.Equal(t, int32(10-limit+1), rejected.Load())
+
+	keys, _, err := store.List(ctx, user.Username, api_keys.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, keys, limit)
+}
+
+func TestServiceIdempotentCreate(t *testing.T) {
+	ctx := t.Context()
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	service := api_keys.NewService(manager, store, 0, time.Hour, nil)
+	user := &token.UserContext{Username: "idem-user", Groups: []string{"system:authenticated"}}
+
+	created, replayed, err := service.CreateAPIKeyIdempotent(ctx, user, "retry-1", "hash-a", "key", "", nil, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Nil(t, replayed)
+
+	t.Run("RetryReplaysOriginalKey", func(t *testing.T) {
+		again, replayed, err := service.CreateAPIKeyIdempotent(ctx, user, "retry-1", "hash-a", "key", "", nil, time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, again, "retry must not mint a new key")
+		require.NotNil(t, replayed)
+		assert.Equal(t, created.JTI, replayed.ID)
+
+		keys, _, err := store.List(ctx, user.Username, api_keys.ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, keys, 1)
+	})
+
+	t.Run("DifferentRequestIsRejected", func(t *testing.T) {
+		_, _, err := service.CreateAPIKeyIdempotent(ctx, user, "retry-1", "hash-b", "other", "", nil, time.Hour)
+		require.ErrorIs(t, err, api_keys.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("KeysAreScopedPerUser", func(t *testing.T) {
+		other := &token.UserContext{Username: "idem-other", Groups: []string{"system:authenticated"}}
+		created, _, err := service.CreateAPIKeyIdempotent(ctx, other, "retry-1", "hash-a", "key", "", nil, time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, created)
+	})
+
+	t.Run("InFlightRequestConflicts", func(t *testing.T) {
+		_, err := store.ReserveIdempotencyKey(ctx, user.Username, "pending", "hash-a", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		_, _, err = service.CreateAPIKeyIdempotent(ctx, user, "pending", "hash-a", "key", "", nil, time.Hour)
+		require.ErrorIs(t, err, api_keys.ErrIdempotencyKeyInProgress)
+	})
+}
+
+func TestServiceUpdateLabels(t *testing.T) {
+	ctx := t.Context()
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	service := api_keys.NewService(manager, store, 0, 0, nil)
+	user := &token.UserContext{Username: "label-user", Groups: []string{"system:authenticated"}}
+
+	created, err := service.CreateAPIKey(ctx, user, "key", "", map[string]string{"env": "prod", "team": "ml"}, time.Hour)
+	require.NoError(t, err)
+
+	value := func(v string) *string { return &v }
+
+	t.Run("MergePatch", func(t *testing.T) {
+		updated, err := service.UpdateLabels(ctx, user, created.JTI, map[string]*string{
+			"env":   value("staging"),
+			"team":  nil,
+			"owner": value("alice"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "staging", "owner": "alice"}, updated.Labels)
+	})
+
+	t.Run("InvalidLabel", func(t *testing.T) {
+		_, err := service.UpdateLabels(ctx, user, created.JTI, map[string]*string{"bad key!": value("x")})
+		require.ErrorIs(t, err, api_keys.ErrInvalidLabels)
+	})
+
+	t.Run("TooManyLabels", func(t *testing.T) {
+		patch := make(map[string]*string, api_keys.MaxLabelsPerKey)
+		for i := range api_keys.MaxLabelsPerKey {
+			patch[fmt.Sprintf("label-%d", i)] = value("x")
+		}
+		_, err := service.UpdateLabels(ctx, user, created.JTI, patch)
+		require.ErrorIs(t, err, api_keys.ErrInvalidLabels, "merged labels exceed the limit")
+	})
+
+	t.Run("AnotherUsersKey", func(t *testing.T) {
+		other := &token.UserContext{Username: "other-user", Groups: []string{"system:authenticated"}}
+		_, err := service.UpdateLabels(ctx, other, created.JTI, map[string]*string{"env": value("dev")})
+		require.ErrorIs(t, err, api_keys.ErrTokenNotFound)
+	})
+}
+
+type recordingSink struct {
+	events   []string
+	data     []api_keys.LifecycleEvent
+	payloads []any
+	// fail makes Deliver reject events without recording them.
+	fail error
+}
+
+func (r *recordingSink) Deliver(_ context.Context, eventType string, data any) error {
+	if r.fail != nil {
+		return r.fail
+	}
+	r.Send(eventType, data)
+	return nil
+}
+
+func (r *recordingSink) Send(eventType string, data any) {
+	r.events = append(r.events, eventType)
+	r.payloads = append(r.payloads, data)
+	if event, ok := data.(api_keys.LifecycleEvent); ok {
+		r.data = append(r.data, event)
+	}
+}
+
+func TestServiceLifecycleEvents(t *testing.T) {
+	ctx := t.Context()
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	sink := &recordingSink{}
+	service := api_keys.NewService(manager, store, 0, 0, sink)
+	user := &token.UserContext{Username: "event-user", Groups: []string{"system:authenticated"}}
+
+	created, err := service.CreateAPIKey(ctx, user, "key", "", map[string]string{"env": "prod"}, time.Hour)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{api_keys.EventKeyCreated}, sink.events)
+	assert.Equal(t, created.JTI, sink.data[0].ID)
+	assert.Equal(t, "key", sink.data[0].Name)
+	assert.Equal(t, user.Username, sink.data[0].Username)
+	assert.Equal(t, map[string]string{"env": "prod"}, sink.data[0].Labels)
+}
+
+func TestServiceRevokeAllEvents(t *testing.T) {
+	ctx := t.Context()
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	sink := &recordingSink{}
+	service := api_keys.NewService(manager, store, 0, 0, sink)
+	user := &token.UserContext{Username: "revoke-user", Groups: []string{"system:authenticated"}}
+
+	var created []string
+	for _, name := range []string{"key-1", "key-2"} {
+		key, err := service.CreateAPIKey(ctx, user, name, "", map[string]string{"env": "prod"}, time.Hour)
+		require.NoError(t, err)
+		created = append(created, key.JTI)
+	}
+	sink.events, sink.data = nil, nil
+
+	require.NoError(t, service.RevokeAll(ctx, user))
+
+	assert.Equal(t, []string{api_keys.EventKeyRevoked, api_keys.EventKeyRevoked}, sink.events)
+	var revoked []string
+	for _, event := range sink.data {
+		assert.Equal(t, user.Username, event.Username)
+		assert.Equal(t, map[string]string{"env": "prod"}, event.Labels)
+		revoked = append(revoked, event.ID)
+	}
+	assert.ElementsMatch(t, created, revoked)
+
+	keys, _, err := store.List(ctx, user.Username, api_keys.ListOptions{})
+	require.NoError(t, err)
+	for _, key := range keys {
+		assert.Equal(t, api_keys.TokenStatusExpired, key.Status)
+	}
+
+	t.Run("AlreadyRevokedKeysAreNotReported", func(t *testing.T) {
+		sink.events, sink.data = nil, nil
+		require.NoError(t, service.RevokeAll(ctx, user))
+		assert.Empty(t, sink.events)
+	})
+}
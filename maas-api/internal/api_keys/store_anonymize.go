@@ -0,0 +1,117 @@
+package api_keys
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+)
+
+// AnonymizeResult counts the rows rewritten by Anonymize.
+type AnonymizeResult struct {
+	Users       int
+	Keys        int
+	LabelValues int
+}
+
+// Anonymize replaces personal data in a copy of the database so that it can be used outside production.
+// Usernames, API key names and label values are replaced with pseudonyms derived from salt with HMAC-SHA256.
+// The same input always maps to the same pseudonym, so a user's keys and audit events stay linked and label
+// selectors keep matching the same keys. Key descriptions are cleared and idempotency records and quota slots are deleted.
+// Key IDs, timestamps, namespaces and tiers are kept.
+//
+// The rewrite runs in one transaction and cannot be undone: never run it against a production database.
+func (s *SQLStore) Anonymize(ctx context.Context, salt []byte) (AnonymizeResult, error) {
+	var result AnonymizeResult
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	usernames, err := queryStrings(ctx, tx, `SELECT username FROM tokens UNION SELECT username FROM token_audit`)
+	if err != nil {
+		return result, fmt.Errorf("failed to list usernames: %w", err)
+	}
+	for _, username := range usernames {
+		pseudonym := "user-" + pseudonymize(salt, "user", username, 16)
+		for _, table := range []string{"tokens", "token_audit"} {
+			//nolint:gosec // G201: Safe - table names are constants, values use placeholders
+			query := fmt.Sprintf(`UPDATE %s SET username = %s WHERE username = %s`, table, s.placeholder(1), s.placeholder(2))
+			if _, err := tx.ExecContext(ctx, query, pseudonym, username); err != nil {
+				return result, fmt.Errorf("failed to anonymize usernames in %s: %w", table, err)
+			}
+		}
+	}
+	result.Users = len(usernames)
+
+	ids, err := queryStrings(ctx, tx, `SELECT id FROM tokens`)
+	if err != nil {
+		return result, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	keyQuery := fmt.Sprintf(`UPDATE tokens SET name = %s, description = '' WHERE id = %s`, s.placeholder(1), s.placeholder(2))
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, keyQuery, "key-"+pseudonymize(salt, "key", id, 8), id); err != nil {
+			return result, fmt.Errorf("failed to anonymize api key: %w", err)
+		}
+	}
+	result.Keys = len(ids)
+
+	values, err := queryStrings(ctx, tx, `SELECT DISTINCT label_value FROM api_key_labels`)
+	if err != nil {
+		return result, fmt.Errorf("failed to list label values: %w", err)
+	}
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	labelQuery := fmt.Sprintf(`UPDATE api_key_labels SET label_value = %s WHERE label_value = %s`, s.placeholder(1), s.placeholder(2))
+	for _, value := range values {
+		// Keep the result a valid label value: alphanumeric at both ends.
+		if _, err := tx.ExecContext(ctx, labelQuery, "v"+pseudonymize(salt, "label", value, 8), value); err != nil {
+			return result, fmt.Errorf("failed to anonymize label values: %w", err)
+		}
+	}
+	result.LabelValues = len(values)
+
+	// Idempotency records and quota slots are short-lived and carry usernames; they are not worth keeping.
+	for _, table := range []string{"api_key_idempotency", "api_key_slots"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+			return result, fmt.Errorf("failed to delete %s records: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("failed to commit anonymization: %w", err)
+	}
+	return result, nil
+}
+
+// pseudonymize returns the first n hex characters of the HMAC of value. kind separates the
+// namespaces of different fields, so that equal inputs in different columns do not correlate.
+func pseudonymize(salt []byte, kind, value string, n int) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:n]
+}
+
+func queryStrings(ctx context.Context, tx *sql.Tx, query string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,134 @@
+package api_keys
+
+import (
+	"context"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/faults"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+// FaultyStore applies the database faults carried by each call's context before delegating
+// to the wrapped store. It is only used when fault injection is enabled in development.
+type FaultyStore struct {
+	MetadataStore
+}
+
+var _ MetadataStore = (*FaultyStore)(nil)
+
+// NewFaultyStore wraps store with fault injection.
+func NewFaultyStore(store MetadataStore) *FaultyStore {
+	return &FaultyStore{MetadataStore: store}
+}
+
+func (f *FaultyStore) Add(ctx context.Context, username string, apiKey *APIKey) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.Add(ctx, username, apiKey)
+}
+
+func (f *FaultyStore) AddIdempotent(ctx context.Context, username string, apiKey *APIKey, idempotencyKey string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.AddIdempotent(ctx, username, apiKey, idempotencyKey)
+}
+
+func (f *FaultyStore) List(ctx context.Context, username string, opts ListOptions) ([]ApiKeyMetadata, int, error) {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return nil, 0, err
+	}
+	return f.MetadataStore.List(ctx, username, opts)
+}
+
+func (f *FaultyStore) Get(ctx context.Context, username, jti string) (*ApiKeyMetadata, error) {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return nil, err
+	}
+	return f.MetadataStore.Get(ctx, username, jti)
+}
+
+func (f *FaultyStore) UpdateLabels(ctx context.Context, username, jti string, set map[string]string, remove []string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.UpdateLabels(ctx, username, jti, set, remove)
+}
+
+func (f *FaultyStore) InvalidateAll(ctx context.Context, username string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.InvalidateAll(ctx, username)
+}
+
+func (f *FaultyStore) ReserveKeySlot(ctx context.Context, username string, limit int) (string, error) {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return "", err
+	}
+	return f.MetadataStore.ReserveKeySlot(ctx, username, limit)
+}
+
+func (f *FaultyStore) ReleaseKeySlot(ctx context.Context, id string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.ReleaseKeySlot(ctx, id)
+}
+
+func (f *FaultyStore) ClaimExpiring(ctx context.Context, before time.Time) ([]ExpiringKey, error) {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return nil, err
+	}
+	return f.MetadataStore.ClaimExpiring(ctx, before)
+}
+
+func (f *FaultyStore) CompleteReminders(ctx context.Context, ids []string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.CompleteReminders(ctx, ids)
+}
+
+func (f *FaultyStore) ReleaseReminders(ctx context.Context, ids []string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.ReleaseReminders(ctx, ids)
+}
+
+func (f *FaultyStore) ReserveIdempotencyKey(ctx context.Context, username, key, requestHash string, notBefore time.Time) (*IdempotencyRecord, error) {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return nil, err
+	}
+	return f.MetadataStore.ReserveIdempotencyKey(ctx, username, key, requestHash, notBefore)
+}
+
+func (f *FaultyStore) ReleaseIdempotencyKey(ctx context.Context, username, key string) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.ReleaseIdempotencyKey(ctx, username, key)
+}
+
+func (f *FaultyStore) RecordAudit(ctx context.Context, event token.AuditEvent) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.RecordAudit(ctx, event)
+}
+
+func (f *FaultyStore) ListAudit(ctx context.Context, namespace, username string, limit int) ([]token.AuditEvent, error) {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return nil, err
+	}
+	return f.MetadataStore.ListAudit(ctx, namespace, username, limit)
+}
+
+func (f *FaultyStore) Ping(ctx context.Context) error {
+	if err := faults.BeforeDB(ctx); err != nil {
+		return err
+	}
+	return f.MetadataStore.Ping(ctx)
+}
@@ -3,6 +3,9 @@ package api_keys
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
 )
 
 var ErrTokenNotFound = errors.New("token not found")
@@ -15,12 +18,60 @@ const (
 type MetadataStore interface {
 	Add(ctx context.Context, username string, apiKey *APIKey) error
 
-	List(ctx context.Context, username string) ([]ApiKeyMetadata, error)
+	// AddIdempotent stores the key like Add and, in the same transaction, associates the user's
+	// reserved idempotency key with it.
+	AddIdempotent(ctx context.Context, username string, apiKey *APIKey, idempotencyKey string) error
+
+	// List returns a page of the user's API keys, filtered and sorted as described by opts,
+	// together with the number of keys matching the filters across all pages.
+	List(ctx context.Context, username string, opts ListOptions) ([]ApiKeyMetadata, int, error)
 
-	Get(ctx context.Context, jti string) (*ApiKeyMetadata, error)
+	// Get returns the API key with the given ID if it is owned by username.
+	// Keys owned by other users are reported as ErrTokenNotFound.
+	Get(ctx context.Context, username, jti string) (*ApiKeyMetadata, error)
+
+	// UpdateLabels sets and removes labels on the user's API key.
+	// Keys owned by other users are reported as ErrTokenNotFound.
+	UpdateLabels(ctx context.Context, username, jti string, set map[string]string, remove []string) error
 
 	// InvalidateAll marks all active tokens for a user as expired.
 	InvalidateAll(ctx context.Context, username string) error
 
+	// ReserveKeySlot atomically checks that the user holds fewer than limit active keys and pending
+	// slots and reserves a slot for a key about to be minted. It returns ErrKeyQuotaExceeded otherwise.
+	ReserveKeySlot(ctx context.Context, username string, limit int) (string, error)
+
+	// ReleaseKeySlot frees a slot once its key has been stored or its creation failed.
+	ReleaseKeySlot(ctx context.Context, id string) error
+
+	// ClaimExpiring claims the keys expiring before the given time that have not been reminded about,
+	// except keys opted out with ReminderOptOutLabel. A claimed key is not returned again until its
+	// claim is released or times out.
+	ClaimExpiring(ctx context.Context, before time.Time) ([]ExpiringKey, error)
+
+	// CompleteReminders records that reminders about the given claimed keys were delivered.
+	CompleteReminders(ctx context.Context, ids []string) error
+
+	// ReleaseReminders releases the claims on keys whose reminder could not be delivered.
+	ReleaseReminders(ctx context.Context, ids []string) error
+
+	// ReserveIdempotencyKey records that a request with the given idempotency key is being processed.
+	// It returns nil if the key was reserved, or the existing record if the key was already used.
+	// Records created before notBefore are discarded first and do not count as used.
+	ReserveIdempotencyKey(ctx context.Context, username, key, requestHash string, notBefore time.Time) (*IdempotencyRecord, error)
+
+	// ReleaseIdempotencyKey removes a reservation so that the request can be retried.
+	ReleaseIdempotencyKey(ctx context.Context, username, key string) error
+
+	// RecordAudit appends a token issuance or revocation event to the audit log.
+	RecordAudit(ctx context.Context, event token.AuditEvent) error
+
+	// ListAudit returns up to limit audit events for the namespace, newest first.
+	// A non-empty username restricts the result to that user's events.
+	ListAudit(ctx context.Context, namespace, username string, limit int) ([]token.AuditEvent, error)
+
+	// Ping verifies the backing database is reachable.
+	Ping(ctx context.Context) error
+
 	Close() error
 }
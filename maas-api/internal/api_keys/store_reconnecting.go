@@ -0,0 +1,270 @@
+package api_keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+// ErrStoreUnavailable is returned while the metadata store has not connected to its database.
+var ErrStoreUnavailable = errors.New("metadata store is unavailable")
+
+const (
+	initialReconnectDelay = time.Second
+	maxReconnectDelay     = 30 * time.Second
+	// connectTimeout bounds each attempt, so an unroutable database host cannot stall startup.
+	connectTimeout = 10 * time.Second
+)
+
+// ReconnectingStore lets the server start while the database is unreachable. It keeps
+// connecting in the background with exponential backoff; until it succeeds, every call
+// fails with ErrStoreUnavailable and the server runs without its database-backed routes.
+type ReconnectingStore struct {
+	connect func(ctx context.Context) (MetadataStore, error)
+	logger  *logger.Logger
+
+	mu      sync.RWMutex
+	store   MetadataStore
+	lastErr error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ MetadataStore = (*ReconnectingStore)(nil)
+
+// NewReconnectingStore makes a first connection attempt and, if it fails, keeps retrying
+// in the background until ctx is cancelled or the store is closed.
+func NewReconnectingStore(ctx context.Context, log *logger.Logger, connect func(ctx context.Context) (MetadataStore, error)) *ReconnectingStore {
+	if log == nil {
+		log = logger.Production()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r := &ReconnectingStore{
+		connect: connect,
+		logger:  log,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	if r.tryConnect(ctx) {
+		close(r.done)
+		return r
+	}
+
+	log.Warn("Database unavailable, starting in degraded mode; API key routes return 503 until it connects",
+		"error", r.lastError(),
+	)
+	go r.reconnect(ctx)
+	return r
+}
+
+// Connected reports whether the database connection has been established.
+func (r *ReconnectingStore) Connected() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.store != nil
+}
+
+func (r *ReconnectingStore) reconnect(ctx context.Context) {
+	defer close(r.done)
+
+	delay := initialReconnectDelay
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if r.tryConnect(ctx) {
+			r.logger.Info("Database connected, leaving degraded mode")
+			return
+		}
+		r.logger.Debug("Database still unavailable",
+			"error", r.lastError(),
+			"retry_in", delay.String(),
+		)
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+func (r *ReconnectingStore) tryConnect(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	store, err := r.connect(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.lastErr = err
+		return false
+	}
+	r.store, r.lastErr = store, nil
+	return true
+}
+
+func (r *ReconnectingStore) lastError() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastErr
+}
+
+//nolint:ireturn // Returns the connected backing store.
+func (r *ReconnectingStore) current() (MetadataStore, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.store == nil {
+		return nil, fmt.Errorf("%w: %w", ErrStoreUnavailable, r.lastErr)
+	}
+	return r.store, nil
+}
+
+func (r *ReconnectingStore) Add(ctx context.Context, username string, apiKey *APIKey) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.Add(ctx, username, apiKey)
+}
+
+func (r *ReconnectingStore) AddIdempotent(ctx context.Context, username string, apiKey *APIKey, idempotencyKey string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.AddIdempotent(ctx, username, apiKey, idempotencyKey)
+}
+
+func (r *ReconnectingStore) List(ctx context.Context, username string, opts ListOptions) ([]ApiKeyMetadata, int, error) {
+	store, err := r.current()
+	if err != nil {
+		return nil, 0, err
+	}
+	return store.List(ctx, username, opts)
+}
+
+func (r *ReconnectingStore) Get(ctx context.Context, username, jti string) (*ApiKeyMetadata, error) {
+	store, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return store.Get(ctx, username, jti)
+}
+
+func (r *ReconnectingStore) UpdateLabels(ctx context.Context, username, jti string, set map[string]string, remove []string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.UpdateLabels(ctx, username, jti, set, remove)
+}
+
+func (r *ReconnectingStore) InvalidateAll(ctx context.Context, username string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.InvalidateAll(ctx, username)
+}
+
+func (r *ReconnectingStore) ReserveKeySlot(ctx context.Context, username string, limit int) (string, error) {
+	store, err := r.current()
+	if err != nil {
+		return "", err
+	}
+	return store.ReserveKeySlot(ctx, username, limit)
+}
+
+func (r *ReconnectingStore) ReleaseKeySlot(ctx context.Context, id string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.ReleaseKeySlot(ctx, id)
+}
+
+func (r *ReconnectingStore) ClaimExpiring(ctx context.Context, before time.Time) ([]ExpiringKey, error) {
+	store, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return store.ClaimExpiring(ctx, before)
+}
+
+func (r *ReconnectingStore) CompleteReminders(ctx context.Context, ids []string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.CompleteReminders(ctx, ids)
+}
+
+func (r *ReconnectingStore) ReleaseReminders(ctx context.Context, ids []string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.ReleaseReminders(ctx, ids)
+}
+
+func (r *ReconnectingStore) ReserveIdempotencyKey(ctx context.Context, username, key, requestHash string, notBefore time.Time) (*IdempotencyRecord, error) {
+	store, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return store.ReserveIdempotencyKey(ctx, username, key, requestHash, notBefore)
+}
+
+func (r *ReconnectingStore) ReleaseIdempotencyKey(ctx context.Context, username, key string) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.ReleaseIdempotencyKey(ctx, username, key)
+}
+
+func (r *ReconnectingStore) RecordAudit(ctx context.Context, event token.AuditEvent) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.RecordAudit(ctx, event)
+}
+
+func (r *ReconnectingStore) ListAudit(ctx context.Context, namespace, username string, limit int) ([]token.AuditEvent, error) {
+	store, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return store.ListAudit(ctx, namespace, username, limit)
+}
+
+func (r *ReconnectingStore) Ping(ctx context.Context) error {
+	store, err := r.current()
+	if err != nil {
+		return err
+	}
+	return store.Ping(ctx)
+}
+
+// Close stops reconnecting and closes the backing store if it was connected.
+func (r *ReconnectingStore) Close() error {
+	r.cancel()
+	<-r.done
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.store == nil {
+		return nil
+	}
+	return r.store.Close()
+}
@@ -0,0 +1,62 @@
+package api_keys_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+func TestReconnectingStore(t *testing.T) {
+	ctx := t.Context()
+	errDown := errors.New("connection refused")
+
+	t.Run("ConnectsImmediately", func(t *testing.T) {
+		store := api_keys.NewReconnectingStore(ctx, logger.Development(), func(context.Context) (api_keys.MetadataStore, error) {
+			return createTestStore(t), nil
+		})
+		defer store.Close()
+
+		assert.True(t, store.Connected())
+		require.NoError(t, store.Ping(ctx))
+	})
+
+	t.Run("DegradedUntilDatabaseReturns", func(t *testing.T) {
+		var attempts atomic.Int32
+		store := api_keys.NewReconnectingStore(ctx, logger.Development(), func(context.Context) (api_keys.MetadataStore, error) {
+			if attempts.Add(1) == 1 {
+				return nil, errDown
+			}
+			return createTestStore(t), nil
+		})
+		defer store.Close()
+
+		assert.False(t, store.Connected())
+		_, _, err := store.List(ctx, "user", api_keys.ListOptions{})
+		require.ErrorIs(t, err, api_keys.ErrStoreUnavailable)
+		require.ErrorIs(t, store.Ping(ctx), errDown, "the connection error is reported")
+
+		require.Eventually(t, store.Connected, 5*time.Second, 50*time.Millisecond)
+		keys, _, err := store.List(ctx, "user", api_keys.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("CloseStopsReconnecting", func(t *testing.T) {
+		var attempts atomic.Int32
+		store := api_keys.NewReconnectingStore(ctx, logger.Development(), func(context.Context) (api_keys.MetadataStore, error) {
+			attempts.Add(1)
+			return nil, errDown
+		})
+
+		require.NoError(t, store.Close())
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+}
@@ -2,15 +2,37 @@ package api_keys
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
 )
 
+// auditTimeFormat is RFC3339 with fixed nanosecond precision, so audit events recorded
+// within the same second still sort correctly as text.
+const auditTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// idempotencyPendingTimeout is how long an in-flight idempotency reservation blocks retries
+// before it is considered abandoned. It exceeds the server write timeout.
+const idempotencyPendingTimeout = time.Minute
+
+// keySlotTimeout is how long a quota slot reserved for a key being minted counts against the quota.
+// It only matters if the replica dies before releasing the slot.
+const keySlotTimeout = time.Minute
+
+// reminderClaimTimeout is how long a claimed reminder is held for its delivery. It exceeds the
+// webhook retry schedule and only matters if the replica dies before completing or releasing it.
+const reminderClaimTimeout = 10 * time.Minute
+
 var (
 	ErrEmptyJTI  = errors.New("token JTI is required and cannot be empty")
 	ErrEmptyName = errors.New("token name is required and cannot be empty")
@@ -67,6 +89,10 @@ func (s *SQLStore) Close() error {
 	return s.db.Close()
 }
 
+func (s *SQLStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 func (s *SQLStore) initSchema(ctx context.Context) error {
 	// Use TEXT for timestamps - works for both SQLite and PostgreSQL
 	// SQLite doesn't have TIMESTAMPTZ, and TEXT is portable
@@ -88,6 +114,92 @@ func (s *SQLStore) initSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to create username index: %w", err)
 	}
 
+	createIdempotencyTableQuery := `
+	CREATE TABLE IF NOT EXISTS api_key_idempotency (
+		username TEXT NOT NULL,
+		idempotency_key TEXT NOT NULL,
+		request_hash TEXT NOT NULL,
+		token_id TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		PRIMARY KEY (username, idempotency_key)
+	)`
+
+	if _, err := s.db.ExecContext(ctx, createIdempotencyTableQuery); err != nil {
+		return fmt.Errorf("failed to create idempotency table: %w", err)
+	}
+
+	// Timestamps use auditTimeFormat, which is fixed-width so that text ordering is chronological.
+	createAuditTableQuery := `
+	CREATE TABLE IF NOT EXISTS token_audit (
+		id TEXT PRIMARY KEY,
+		action TEXT NOT NULL,
+		username TEXT NOT NULL,
+		namespace TEXT NOT NULL,
+		tier TEXT NOT NULL,
+		token_id TEXT NOT NULL DEFAULT '',
+		expiration_date TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL
+	)`
+
+	if _, err := s.db.ExecContext(ctx, createAuditTableQuery); err != nil {
+		return fmt.Errorf("failed to create audit table: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_token_audit_namespace ON token_audit(namespace, created_at)`); err != nil {
+		return fmt.Errorf("failed to create audit namespace index: %w", err)
+	}
+
+	createLabelsTableQuery := `
+	CREATE TABLE IF NOT EXISTS api_key_labels (
+		token_id TEXT NOT NULL,
+		label_key TEXT NOT NULL,
+		label_value TEXT NOT NULL,
+		PRIMARY KEY (token_id, label_key)
+	)`
+
+	if _, err := s.db.ExecContext(ctx, createLabelsTableQuery); err != nil {
+		return fmt.Errorf("failed to create labels table: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_api_key_labels_label ON api_key_labels(label_key, label_value)`); err != nil {
+		return fmt.Errorf("failed to create labels index: %w", err)
+	}
+
+	createSlotsTableQuery := `
+	CREATE TABLE IF NOT EXISTS api_key_slots (
+		id TEXT PRIMARY KEY,
+		username TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	)`
+
+	if _, err := s.db.ExecContext(ctx, createSlotsTableQuery); err != nil {
+		return fmt.Errorf("failed to create key slots table: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_api_key_slots_username ON api_key_slots(username)`); err != nil {
+		return fmt.Errorf("failed to create key slots index: %w", err)
+	}
+
+	createRemindersTableQuery := `
+	CREATE TABLE IF NOT EXISTS api_key_reminders (
+		token_id TEXT PRIMARY KEY,
+		sent_at TEXT NOT NULL
+	)`
+
+	if _, err := s.db.ExecContext(ctx, createRemindersTableQuery); err != nil {
+		return fmt.Errorf("failed to create reminders table: %w", err)
+	}
+
+	createReminderClaimsTableQuery := `
+	CREATE TABLE IF NOT EXISTS api_key_reminder_claims (
+		token_id TEXT PRIMARY KEY,
+		claimed_at TEXT NOT NULL
+	)`
+
+	if _, err := s.db.ExecContext(ctx, createReminderClaimsTableQuery); err != nil {
+		return fmt.Errorf("failed to create reminder claims table: %w", err)
+	}
+
 	return nil
 }
 
@@ -98,6 +210,16 @@ func (s *SQLStore) placeholder(index int) string {
 }
 
 func (s *SQLStore) Add(ctx context.Context, username string, apiKey *APIKey) error {
+	return s.add(ctx, username, apiKey, "")
+}
+
+// AddIdempotent stores the key and completes the user's idempotency reservation for it in the
+// same transaction, so a stored key is always replayable.
+func (s *SQLStore) AddIdempotent(ctx context.Context, username string, apiKey *APIKey, idempotencyKey string) error {
+	return s.add(ctx, username, apiKey, idempotencyKey)
+}
+
+func (s *SQLStore) add(ctx context.Context, username string, apiKey *APIKey, idempotencyKey string) error {
 	jti := strings.TrimSpace(apiKey.JTI)
 	if jti == "" {
 		return ErrEmptyJTI
@@ -126,11 +248,79 @@ func (s *SQLStore) Add(ctx context.Context, username string, apiKey *APIKey) err
 	VALUES (%s, %s, %s, %s, %s, %s)
 	`, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4), s.placeholder(5), s.placeholder(6))
 
-	description := strings.TrimSpace(apiKey.Description)
-	_, err := s.db.ExecContext(ctx, query, jti, username, name, description, creationStr, expirationStr)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	description := strings.TrimSpace(apiKey.Description)
+	if _, err := tx.ExecContext(ctx, query, jti, username, name, description, creationStr, expirationStr); err != nil {
 		return fmt.Errorf("failed to insert token metadata: %w", err)
 	}
+
+	if err := s.upsertLabels(ctx, tx, jti, apiKey.Labels); err != nil {
+		return err
+	}
+
+	if idempotencyKey != "" {
+		//nolint:gosec // G201: Safe - using placeholder indices, not user input
+		completeQuery := fmt.Sprintf(`UPDATE api_key_idempotency SET token_id = %s WHERE username = %s AND idempotency_key = %s`,
+			s.placeholder(1), s.placeholder(2), s.placeholder(3))
+		if _, err := tx.ExecContext(ctx, completeQuery, jti, username, idempotencyKey); err != nil {
+			return fmt.Errorf("failed to complete idempotency key: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateLabels sets and removes labels on the user's API key in a single transaction.
+func (s *SQLStore) UpdateLabels(ctx context.Context, username, jti string, set map[string]string, remove []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	ownerQuery := fmt.Sprintf(`SELECT id FROM tokens WHERE id = %s AND username = %s`, s.placeholder(1), s.placeholder(2))
+	var id string
+	if err := tx.QueryRowContext(ctx, ownerQuery, jti, username).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTokenNotFound
+		}
+		return fmt.Errorf("failed to look up token: %w", err)
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	deleteQuery := fmt.Sprintf(`DELETE FROM api_key_labels WHERE token_id = %s AND label_key = %s`, s.placeholder(1), s.placeholder(2))
+	for _, key := range remove {
+		if _, err := tx.ExecContext(ctx, deleteQuery, jti, key); err != nil {
+			return fmt.Errorf("failed to remove label %q: %w", key, err)
+		}
+	}
+
+	if err := s.upsertLabels(ctx, tx, jti, set); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLStore) upsertLabels(ctx context.Context, tx *sql.Tx, jti string, labels map[string]string) error {
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`
+	INSERT INTO api_key_labels (token_id, label_key, label_value)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (token_id, label_key) DO UPDATE SET label_value = excluded.label_value
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3))
+
+	for key, value := range labels {
+		if _, err := tx.ExecContext(ctx, query, jti, key, value); err != nil {
+			return fmt.Errorf("failed to store label %q: %w", key, err)
+		}
+	}
 	return nil
 }
 
@@ -154,18 +344,376 @@ func (s *SQLStore) InvalidateAll(ctx context.Context, username string) error {
 	return nil
 }
 
-func (s *SQLStore) List(ctx context.Context, username string) ([]ApiKeyMetadata, error) {
+// ReserveKeySlot counts the user's active keys and pending slots and, if they are below limit,
+// records a new slot, all in one transaction. SQLite serializes transactions on its single
+// connection; PostgreSQL takes a per-user advisory lock, so concurrent requests cannot both pass.
+func (s *SQLStore) ReserveKeySlot(ctx context.Context, username string, limit int) (string, error) {
+	now := time.Now().UTC()
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", fmt.Errorf("failed to generate slot id: %w", err)
+	}
+	id := hex.EncodeToString(idBytes)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if s.dbType == DBTypePostgres {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "api-key-slots:"+username); err != nil {
+			return "", fmt.Errorf("failed to lock key quota: %w", err)
+		}
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	countQuery := fmt.Sprintf(`
+	SELECT
+		(SELECT COUNT(*) FROM tokens WHERE username = %s AND expiration_date > %s) +
+		(SELECT COUNT(*) FROM api_key_slots WHERE username = %s AND created_at > %s)
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4))
+
+	var used int
+	if err := tx.QueryRowContext(ctx, countQuery,
+		username, now.Format(time.RFC3339), username, now.Add(-keySlotTimeout).Format(time.RFC3339),
+	).Scan(&used); err != nil {
+		return "", fmt.Errorf("failed to count active keys: %w", err)
+	}
+	if used >= limit {
+		return "", fmt.Errorf("%w: user has %d active keys (limit %d)", ErrKeyQuotaExceeded, used, limit)
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	insertQuery := fmt.Sprintf(`INSERT INTO api_key_slots (id, username, created_at) VALUES (%s, %s, %s)`,
+		s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	if _, err := tx.ExecContext(ctx, insertQuery, id, username, now.Format(time.RFC3339)); err != nil {
+		return "", fmt.Errorf("failed to reserve key slot: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to reserve key slot: %w", err)
+	}
+	return id, nil
+}
+
+func (s *SQLStore) ReleaseKeySlot(ctx context.Context, id string) error {
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`DELETE FROM api_key_slots WHERE id = %s`, s.placeholder(1))
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to release key slot: %w", err)
+	}
+	return nil
+}
+
+// ClaimExpiring returns the keys expiring before the given time that have not been reminded about
+// and are not claimed by another delivery, skipping keys labelled with ReminderOptOutLabel=false.
+// Claims expire after reminderClaimTimeout, so a replica dying mid-delivery does not lose reminders.
+func (s *SQLStore) ClaimExpiring(ctx context.Context, before time.Time) ([]ExpiringKey, error) {
+	now := time.Now().UTC()
+	claimCutoff := now.Add(-reminderClaimTimeout).Format(time.RFC3339)
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`
+	SELECT id, username, name, expiration_date
+	FROM tokens
+	WHERE expiration_date > %s AND expiration_date <= %s
+	AND id NOT IN (SELECT token_id FROM api_key_reminders)
+	AND id NOT IN (SELECT token_id FROM api_key_reminder_claims WHERE claimed_at >= %s)
+	AND id NOT IN (SELECT token_id FROM api_key_labels WHERE label_key = %s AND label_value = 'false')
+	ORDER BY username, expiration_date
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4))
+
+	rows, err := s.db.QueryContext(ctx, query, now.Format(time.RFC3339), before.UTC().Format(time.RFC3339), claimCutoff, ReminderOptOutLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expiring keys: %w", err)
+	}
+	var candidates []ExpiringKey
+	for rows.Next() {
+		var key ExpiringKey
+		var expirationStr string
+		if err := rows.Scan(&key.ID, &key.Username, &key.Name, &expirationStr); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if key.ExpiresAt, err = types.ParseTimestamp(expirationStr); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("token %s has invalid expiration date: %w", key.ID, err)
+		}
+		candidates = append(candidates, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Take over claims that expired, or insert new ones; either succeeds for only one replica.
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	takeOverQuery := fmt.Sprintf(`UPDATE api_key_reminder_claims SET claimed_at = %s WHERE token_id = %s AND claimed_at < %s`,
+		s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	claimQuery := fmt.Sprintf(`INSERT INTO api_key_reminder_claims (token_id, claimed_at) VALUES (%s, %s) ON CONFLICT (token_id) DO NOTHING`,
+		s.placeholder(1), s.placeholder(2))
+
+	claimed := []ExpiringKey{}
+	for _, key := range candidates {
+		result, err := s.db.ExecContext(ctx, takeOverQuery, now.Format(time.RFC3339), key.ID, claimCutoff)
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim expiring key: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 1 {
+			claimed = append(claimed, key)
+			continue
+		}
+
+		result, err = s.db.ExecContext(ctx, claimQuery, key.ID, now.Format(time.RFC3339))
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim expiring key: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 1 {
+			claimed = append(claimed, key)
+		}
+	}
+	return claimed, nil
+}
+
+// CompleteReminders records that reminders about the given keys were delivered and drops their claims.
+func (s *SQLStore) CompleteReminders(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	sentQuery := fmt.Sprintf(`INSERT INTO api_key_reminders (token_id, sent_at) VALUES (%s, %s) ON CONFLICT (token_id) DO NOTHING`,
+		s.placeholder(1), s.placeholder(2))
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	releaseQuery := fmt.Sprintf(`DELETE FROM api_key_reminder_claims WHERE token_id = %s`, s.placeholder(1))
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, sentQuery, id, now); err != nil {
+			return fmt.Errorf("failed to record reminder: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, releaseQuery, id); err != nil {
+			return fmt.Errorf("failed to release reminder claim: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reminders: %w", err)
+	}
+	return nil
+}
+
+// ReleaseReminders drops the claims on the given keys so that the next ClaimExpiring returns them again.
+func (s *SQLStore) ReleaseReminders(ctx context.Context, ids []string) error {
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`DELETE FROM api_key_reminder_claims WHERE token_id = %s`, s.placeholder(1))
+	for _, id := range ids {
+		if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to release reminder claim: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) ReserveIdempotencyKey(ctx context.Context, username, key, requestHash string, notBefore time.Time) (*IdempotencyRecord, error) {
+	now := time.Now().UTC()
+
+	// Discard the caller's records that fell out of the replay window, and reservations whose
+	// request never completed (e.g. the replica crashed mid-request), so the key can be reused.
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	cleanupQuery := fmt.Sprintf(`
+	DELETE FROM api_key_idempotency
+	WHERE username = %s AND (created_at < %s OR (token_id = '' AND created_at < %s))
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3))
+
+	pendingCutoff := now.Add(-idempotencyPendingTimeout).Format(time.RFC3339)
+	if _, err := s.db.ExecContext(ctx, cleanupQuery, username, notBefore.UTC().Format(time.RFC3339), pendingCutoff); err != nil {
+		return nil, fmt.Errorf("failed to discard stale idempotency keys: %w", err)
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	insertQuery := fmt.Sprintf(`
+	INSERT INTO api_key_idempotency (username, idempotency_key, request_hash, created_at)
+	VALUES (%s, %s, %s, %s)
+	ON CONFLICT (username, idempotency_key) DO NOTHING
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4))
+
+	result, err := s.db.ExecContext(ctx, insertQuery, username, key, requestHash, now.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if inserted > 0 {
+		return nil, nil
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	selectQuery := fmt.Sprintf(`
+	SELECT request_hash, token_id, created_at
+	FROM api_key_idempotency
+	WHERE username = %s AND idempotency_key = %s
+	`, s.placeholder(1), s.placeholder(2))
+
+	var record IdempotencyRecord
+	var createdStr string
+	if err := s.db.QueryRowContext(ctx, selectQuery, username, key).Scan(&record.RequestHash, &record.JTI, &createdStr); err != nil {
+		return nil, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+	if record.CreatedAt, err = time.Parse(time.RFC3339, createdStr); err != nil {
+		return nil, fmt.Errorf("idempotency key has invalid creation date: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (s *SQLStore) ReleaseIdempotencyKey(ctx context.Context, username, key string) error {
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`DELETE FROM api_key_idempotency WHERE username = %s AND idempotency_key = %s`,
+		s.placeholder(1), s.placeholder(2))
+
+	if _, err := s.db.ExecContext(ctx, query, username, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// RecordAudit stores the event; its Timestamp is ignored and the current time is recorded instead.
+func (s *SQLStore) RecordAudit(ctx context.Context, event token.AuditEvent) error {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Errorf("failed to generate audit event id: %w", err)
+	}
+
+	var expirationStr string
+	if !event.ExpiresAt.IsZero() {
+		expirationStr = event.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`
+	INSERT INTO token_audit (id, action, username, namespace, tier, token_id, expiration_date, created_at)
+	VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+		s.placeholder(5), s.placeholder(6), s.placeholder(7), s.placeholder(8))
+
+	_, err := s.db.ExecContext(ctx, query, hex.EncodeToString(id), event.Action, event.Username, event.Namespace,
+		event.Tier, event.JTI, expirationStr, time.Now().UTC().Format(auditTimeFormat))
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) ListAudit(ctx context.Context, namespace, username string, limit int) ([]token.AuditEvent, error) {
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	query := fmt.Sprintf(`
+	SELECT action, username, namespace, tier, token_id, expiration_date, created_at
+	FROM token_audit
+	WHERE namespace = %s AND (%s = '' OR username = %s)
+	ORDER BY created_at DESC
+	LIMIT %s
+	`, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4))
+
+	rows, err := s.db.QueryContext(ctx, query, namespace, username, username, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []token.AuditEvent{}
+	for rows.Next() {
+		var event token.AuditEvent
+		var expirationStr, createdStr string
+		if err := rows.Scan(&event.Action, &event.Username, &event.Namespace, &event.Tier,
+			&event.JTI, &expirationStr, &createdStr); err != nil {
+			return nil, err
+		}
+		if expirationStr != "" {
+			if event.ExpiresAt, err = types.ParseTimestamp(expirationStr); err != nil {
+				return nil, fmt.Errorf("audit event has invalid expiration date: %w", err)
+			}
+		}
+		if event.Timestamp, err = types.ParseTimestamp(createdStr); err != nil {
+			return nil, fmt.Errorf("audit event has invalid timestamp: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (s *SQLStore) List(ctx context.Context, username string, opts ListOptions) ([]ApiKeyMetadata, int, error) {
+	orderBy, err := opts.orderBy()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	args := []any{username}
+	var filters strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(opts.Selector)) {
+		//nolint:gosec // G201: Safe - using placeholder indices, not user input
+		fmt.Fprintf(&filters, `
+	AND id IN (SELECT token_id FROM api_key_labels WHERE label_key = %s AND label_value = %s)`,
+			s.placeholder(len(args)+1), s.placeholder(len(args)+2))
+		args = append(args, key, opts.Selector[key])
+	}
+	if opts.Name != "" {
+		//nolint:gosec // G201: Safe - using placeholder indices, not user input
+		fmt.Fprintf(&filters, `
+	AND LOWER(name) LIKE %s ESCAPE '\'`, s.placeholder(len(args)+1))
+		args = append(args, "%"+escapeLike(strings.ToLower(opts.Name))+"%")
+	}
+
 	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM tokens WHERE username = %s%s`, s.placeholder(1), filters.String())
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	var page string
+	if opts.Limit > 0 {
+		//nolint:gosec // G201: Safe - using placeholder indices, not user input
+		page = fmt.Sprintf(`
+	LIMIT %s OFFSET %s`, s.placeholder(len(args)+1), s.placeholder(len(args)+2))
+		args = append(args, opts.Limit, opts.Offset)
+	} else if opts.Offset > 0 {
+		// SQLite only accepts OFFSET after LIMIT; -1 means no limit there, PostgreSQL takes ALL.
+		noLimit := "-1"
+		if s.dbType == DBTypePostgres {
+			noLimit = "ALL"
+		}
+		//nolint:gosec // G201: Safe - using placeholder indices, not user input
+		page = fmt.Sprintf(`
+	LIMIT %s OFFSET %s`, noLimit, s.placeholder(len(args)+1))
+		args = append(args, opts.Offset)
+	}
+
+	//nolint:gosec // G201: Safe - ORDER BY is built from a fixed column list, values use placeholders
 	query := fmt.Sprintf(`
 	SELECT id, name, COALESCE(description, ''), creation_date, expiration_date
 	FROM tokens 
-	WHERE username = %s
-	ORDER BY creation_date DESC
-	`, s.placeholder(1))
+	WHERE username = %s%s
+	ORDER BY %s%s
+	`, s.placeholder(1), filters.String(), orderBy, page)
 
-	rows, err := s.db.QueryContext(ctx, query, username)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -173,55 +721,130 @@ func (s *SQLStore) List(ctx context.Context, username string) ([]ApiKeyMetadata,
 	tokens := []ApiKeyMetadata{}
 
 	for rows.Next() {
-		var t ApiKeyMetadata
-		var creationStr, expirationStr string
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &creationStr, &expirationStr); err != nil {
-			return nil, err
+		t, err := scanMetadata(rows, now)
+		if err != nil {
+			return nil, 0, err
 		}
+		tokens = append(tokens, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(tokens) == 0 {
+		return tokens, total, nil
+	}
+
+	// Only load labels for the keys on this page.
+	ids := make([]string, len(tokens))
+	idArgs := make([]any, len(tokens))
+	for i := range tokens {
+		ids[i] = s.placeholder(i + 1)
+		idArgs[i] = tokens[i].ID
+	}
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	labelsQuery := fmt.Sprintf(`
+	SELECT token_id, label_key, label_value
+	FROM api_key_labels
+	WHERE token_id IN (%s)
+	`, strings.Join(ids, ", "))
 
-		t.CreationDate = creationStr
-		t.ExpirationDate = expirationStr
-		t.Status = computeTokenStatus(expirationStr, now)
+	labels, err := s.queryLabels(ctx, labelsQuery, idArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range tokens {
+		tokens[i].Labels = labels[tokens[i].ID]
+	}
+
+	return tokens, total, nil
+}
+
+// escapeLike escapes the LIKE wildcards in value so that it matches literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// queryLabels runs a query returning (token_id, label_key, label_value) rows and groups them by token.
+func (s *SQLStore) queryLabels(ctx context.Context, query string, args ...any) (map[string]map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labels: %w", err)
+	}
+	defer rows.Close()
 
-		tokens = append(tokens, t)
+	labels := make(map[string]map[string]string)
+	for rows.Next() {
+		var id, key, value string
+		if err := rows.Scan(&id, &key, &value); err != nil {
+			return nil, err
+		}
+		if labels[id] == nil {
+			labels[id] = make(map[string]string)
+		}
+		labels[id][key] = value
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return tokens, nil
+	return labels, nil
 }
 
-func (s *SQLStore) Get(ctx context.Context, jti string) (*ApiKeyMetadata, error) {
+func (s *SQLStore) Get(ctx context.Context, username, jti string) (*ApiKeyMetadata, error) {
 	//nolint:gosec // G201: Safe - using placeholder indices, not user input
 	query := fmt.Sprintf(`
 	SELECT id, name, COALESCE(description, ''), creation_date, expiration_date
 	FROM tokens 
-	WHERE id = %s
-	`, s.placeholder(1))
+	WHERE id = %s AND username = %s
+	`, s.placeholder(1), s.placeholder(2))
+
+	row := s.db.QueryRowContext(ctx, query, jti, username)
 
-	row := s.db.QueryRowContext(ctx, query, jti)
+	t, err := scanMetadata(row, time.Now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, err
+	}
+
+	//nolint:gosec // G201: Safe - using placeholder indices, not user input
+	labelsQuery := fmt.Sprintf(`SELECT token_id, label_key, label_value FROM api_key_labels WHERE token_id = %s`, s.placeholder(1))
+	labels, err := s.queryLabels(ctx, labelsQuery, jti)
+	if err != nil {
+		return nil, err
+	}
+	t.Labels = labels[jti]
+
+	return t, nil
+}
 
+// scanMetadata reads a row of (id, name, description, creation_date, expiration_date)
+// and normalizes the stored RFC3339 strings into UTC timestamps.
+func scanMetadata(row interface{ Scan(dest ...any) error }, now time.Time) (*ApiKeyMetadata, error) {
 	var t ApiKeyMetadata
 	var creationStr, expirationStr string
 	if err := row.Scan(&t.ID, &t.Name, &t.Description, &creationStr, &expirationStr); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrTokenNotFound
-		}
 		return nil, err
 	}
 
-	t.CreationDate = creationStr
-	t.ExpirationDate = expirationStr
-	t.Status = computeTokenStatus(expirationStr, time.Now())
+	var err error
+	if t.CreationDate, err = types.ParseTimestamp(creationStr); err != nil {
+		return nil, fmt.Errorf("token %s has invalid creation date: %w", t.ID, err)
+	}
+	if t.ExpirationDate, err = types.ParseTimestamp(expirationStr); err != nil {
+		return nil, fmt.Errorf("token %s has invalid expiration date: %w", t.ID, err)
+	}
+	t.Status = computeTokenStatus(t.ExpirationDate.Time, now)
 
 	return &t, nil
 }
 
-func computeTokenStatus(expirationStr string, now time.Time) string {
-	expirationDate, err := time.Parse(time.RFC3339, expirationStr)
-	if err != nil || now.After(expirationDate) {
+func computeTokenStatus(expirationDate time.Time, now time.Time) string {
+	if now.After(expirationDate) {
 		return TokenStatusExpired
 	}
 	return TokenStatusActive
@@ -39,11 +39,14 @@ func TestStore(t *testing.T) {
 		err := store.Add(ctx, "user1", apiKey)
 		require.NoError(t, err)
 
-		tokens, err := store.List(ctx, "user1")
+		tokens, _, err := store.List(ctx, "user1", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, tokens, 1)
 		assert.Equal(t, "token1", tokens[0].Name)
 		assert.Equal(t, api_keys.TokenStatusActive, tokens[0].Status)
+		assert.Equal(t, time.UTC, tokens[0].ExpirationDate.Location())
+		assert.Equal(t, apiKey.ExpiresAt, tokens[0].ExpirationDate.Unix())
+		assert.False(t, tokens[0].CreationDate.IsZero())
 	})
 
 	t.Run("AddSecondToken", func(t *testing.T) {
@@ -57,7 +60,7 @@ func TestStore(t *testing.T) {
 		err := store.Add(ctx, "user1", apiKey)
 		require.NoError(t, err)
 
-		tokens, err := store.List(ctx, "user1")
+		tokens, _, err := store.List(ctx, "user1", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, tokens, 2)
 	})
@@ -73,7 +76,7 @@ func TestStore(t *testing.T) {
 		err := store.Add(ctx, "user2", apiKey)
 		require.NoError(t, err)
 
-		tokens, err := store.List(ctx, "user2")
+		tokens, _, err := store.List(ctx, "user2", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, tokens, 1)
 		assert.Equal(t, "token3", tokens[0].Name)
@@ -83,7 +86,7 @@ func TestStore(t *testing.T) {
 		err := store.InvalidateAll(ctx, "user1")
 		require.NoError(t, err)
 
-		tokens, err := store.List(ctx, "user1")
+		tokens, _, err := store.List(ctx, "user1", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, tokens, 2)
 		for _, tok := range tokens {
@@ -91,18 +94,23 @@ func TestStore(t *testing.T) {
 		}
 
 		// User2 should still exist
-		tokens2, err := store.List(ctx, "user2")
+		tokens2, _, err := store.List(ctx, "user2", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, tokens2, 1)
 	})
 
 	t.Run("GetToken", func(t *testing.T) {
-		gotToken, err := store.Get(ctx, "jti3")
+		gotToken, err := store.Get(ctx, "user2", "jti3")
 		require.NoError(t, err)
 		assert.NotNil(t, gotToken)
 		assert.Equal(t, "token3", gotToken.Name)
 	})
 
+	t.Run("GetTokenOwnedByAnotherUser", func(t *testing.T) {
+		_, err := store.Get(ctx, "user1", "jti3")
+		require.ErrorIs(t, err, api_keys.ErrTokenNotFound)
+	})
+
 	t.Run("ExpiredTokenStatus", func(t *testing.T) {
 		apiKey := &api_keys.APIKey{
 			Token: token.Token{
@@ -114,13 +122,13 @@ func TestStore(t *testing.T) {
 		err := store.Add(ctx, "user4", apiKey)
 		require.NoError(t, err)
 
-		tokens, err := store.List(ctx, "user4")
+		tokens, _, err := store.List(ctx, "user4", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, tokens, 1)
 		assert.Equal(t, api_keys.TokenStatusExpired, tokens[0].Status)
 
 		// Get single token check
-		gotToken, err := store.Get(ctx, "jti-expired")
+		gotToken, err := store.Get(ctx, "user4", "jti-expired")
 		require.NoError(t, err)
 		assert.Equal(t, api_keys.TokenStatusExpired, gotToken.Status)
 	})
@@ -158,7 +166,7 @@ func TestStoreValidation(t *testing.T) {
 	})
 
 	t.Run("TokenNotFound", func(t *testing.T) {
-		_, err := store.Get(ctx, "nonexistent-jti")
+		_, err := store.Get(ctx, "user1", "nonexistent-jti")
 		require.Error(t, err)
 		assert.Equal(t, api_keys.ErrTokenNotFound, err)
 	})
@@ -173,7 +181,7 @@ func TestSQLiteStore(t *testing.T) {
 		require.NoError(t, err)
 		defer store.Close()
 
-		tokens, err := store.List(ctx, "user")
+		tokens, _, err := store.List(ctx, "user", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Empty(t, tokens)
 	})
@@ -184,7 +192,7 @@ func TestSQLiteStore(t *testing.T) {
 		require.NoError(t, err)
 		defer store.Close()
 
-		tokens, err := store.List(ctx, "user")
+		tokens, _, err := store.List(ctx, "user", api_keys.ListOptions{})
 		require.NoError(t, err)
 		assert.Empty(t, tokens)
 	})
@@ -206,3 +214,316 @@ func TestExternalStore(t *testing.T) {
 		assert.Contains(t, err.Error(), "unsupported external database URL")
 	})
 }
+
+func TestStoreAudit(t *testing.T) {
+	ctx := t.Context()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	events := []token.AuditEvent{
+		{Action: token.AuditActionIssue, Username: "alice", Namespace: "maas-free", Tier: "free", JTI: "jti-a"},
+		{Action: token.AuditActionIssue, Username: "bob", Namespace: "maas-free", Tier: "free", JTI: "jti-b"},
+		{Action: token.AuditActionRevoke, Username: "alice", Namespace: "maas-free", Tier: "free"},
+		{Action: token.AuditActionIssue, Username: "carol", Namespace: "maas-premium", Tier: "premium", JTI: "jti-c"},
+	}
+	events[0].ExpiresAt.Time = expiresAt
+	for _, event := range events {
+		require.NoError(t, store.RecordAudit(ctx, event))
+	}
+
+	t.Run("ListsNamespaceNewestFirst", func(t *testing.T) {
+		got, err := store.ListAudit(ctx, "maas-free", "", 10)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, token.AuditActionRevoke, got[0].Action)
+		assert.Equal(t, "jti-b", got[1].JTI)
+		assert.Equal(t, "jti-a", got[2].JTI)
+		assert.True(t, expiresAt.Equal(got[2].ExpiresAt.Time))
+		assert.True(t, got[0].ExpiresAt.IsZero(), "revocations carry no expiry")
+		assert.False(t, got[0].Timestamp.IsZero())
+	})
+
+	t.Run("FiltersByUser", func(t *testing.T) {
+		got, err := store.ListAudit(ctx, "maas-free", "alice", 10)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		for _, event := range got {
+			assert.Equal(t, "alice", event.Username)
+		}
+	})
+
+	t.Run("AppliesLimit", func(t *testing.T) {
+		got, err := store.ListAudit(ctx, "maas-free", "", 1)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, token.AuditActionRevoke, got[0].Action)
+	})
+
+	t.Run("UnknownNamespace", func(t *testing.T) {
+		got, err := store.ListAudit(ctx, "maas-unknown", "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestStoreLabels(t *testing.T) {
+	ctx := t.Context()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	add := func(t *testing.T, username, jti string, labels map[string]string) {
+		t.Helper()
+		err := store.Add(ctx, username, &api_keys.APIKey{
+			Token:  token.Token{JTI: jti, ExpiresAt: time.Now().Add(time.Hour).Unix()},
+			Name:   jti,
+			Labels: labels,
+		})
+		require.NoError(t, err)
+	}
+
+	add(t, "user1", "prod-ml", map[string]string{"env": "prod", "team": "ml"})
+	add(t, "user1", "prod-web", map[string]string{"env": "prod", "team": "web"})
+	add(t, "user1", "unlabeled", nil)
+	add(t, "user2", "other-prod", map[string]string{"env": "prod"})
+
+	ids := func(keys []api_keys.ApiKeyMetadata) []string {
+		var out []string
+		for _, k := range keys {
+			out = append(out, k.ID)
+		}
+		return out
+	}
+
+	t.Run("ListReturnsLabels", func(t *testing.T) {
+		keys, _, err := store.List(ctx, "user1", api_keys.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+		for _, k := range keys {
+			switch k.ID {
+			case "prod-ml":
+				assert.Equal(t, map[string]string{"env": "prod", "team": "ml"}, k.Labels)
+			case "unlabeled":
+				assert.Nil(t, k.Labels)
+			}
+		}
+	})
+
+	t.Run("ListPageReturnsLabels", func(t *testing.T) {
+		want := map[string]map[string]string{
+			"prod-ml":   {"env": "prod", "team": "ml"},
+			"prod-web":  {"env": "prod", "team": "web"},
+			"unlabeled": nil,
+		}
+		for offset := range 3 {
+			keys, _, err := store.List(ctx, "user1", api_keys.ListOptions{Limit: 1, Offset: offset})
+			require.NoError(t, err)
+			require.Len(t, keys, 1)
+			assert.Equal(t, want[keys[0].ID], keys[0].Labels)
+		}
+	})
+
+	t.Run("ListFiltersByLabel", func(t *testing.T) {
+		keys, _, err := store.List(ctx, "user1", api_keys.ListOptions{Selector: map[string]string{"env": "prod"}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"prod-ml", "prod-web"}, ids(keys))
+
+		keys, _, err = store.List(ctx, "user1", api_keys.ListOptions{Selector: map[string]string{"env": "prod", "team": "ml"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod-ml"}, ids(keys))
+
+		keys, _, err = store.List(ctx, "user1", api_keys.ListOptions{Selector: map[string]string{"env": "dev"}})
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("UpdateLabels", func(t *testing.T) {
+		err := store.UpdateLabels(ctx, "user1", "prod-ml", map[string]string{"env": "staging", "owner": "alice"}, []string{"team"})
+		require.NoError(t, err)
+
+		key, err := store.Get(ctx, "user1", "prod-ml")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "staging", "owner": "alice"}, key.Labels)
+	})
+
+	t.Run("UpdateLabelsOfAnotherUsersKey", func(t *testing.T) {
+		err := store.UpdateLabels(ctx, "user1", "other-prod", map[string]string{"env": "dev"}, nil)
+		require.ErrorIs(t, err, api_keys.ErrTokenNotFound)
+
+		key, err := store.Get(ctx, "user2", "other-prod")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod"}, key.Labels)
+	})
+}
+
+func TestStoreAnonymize(t *testing.T) {
+	ctx := t.Context()
+
+	store, err := api_keys.NewSQLiteStore(ctx, logger.Development(), ":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+
+	for _, key := range []struct{ user, jti, env string }{
+		{"alice@example.com", "jti-a1", "prod"},
+		{"alice@example.com", "jti-a2", "prod"},
+		{"bob@example.com", "jti-b1", "staging"},
+	} {
+		require.NoError(t, store.Add(ctx, key.user, &api_keys.APIKey{
+			Token:       token.Token{JTI: key.jti, ExpiresAt: time.Now().Add(time.Hour).Unix()},
+			Name:        "laptop of " + key.user,
+			Description: "personal key",
+			Labels:      map[string]string{"env": key.env},
+		}))
+	}
+	require.NoError(t, store.RecordAudit(ctx, token.AuditEvent{
+		Action: token.AuditActionIssue, Username: "alice@example.com", Namespace: "maas-free", Tier: "free", JTI: "jti-a1",
+	}))
+	_, err = store.ReserveIdempotencyKey(ctx, "alice@example.com", "retry-1", "hash", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	result, err := store.Anonymize(ctx, []byte("salt"))
+	require.NoError(t, err)
+	assert.Equal(t, api_keys.AnonymizeResult{Users: 2, Keys: 3, LabelValues: 2}, result)
+
+	t.Run("OriginalUsersAreGone", func(t *testing.T) {
+		keys, _, err := store.List(ctx, "alice@example.com", api_keys.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("KeysStayLinked", func(t *testing.T) {
+		audit, err := store.ListAudit(ctx, "maas-free", "", 10)
+		require.NoError(t, err)
+		require.Len(t, audit, 1)
+		pseudonym := audit[0].Username
+		assert.NotContains(t, pseudonym, "alice")
+
+		keys, _, err := store.List(ctx, pseudonym, api_keys.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, keys, 2, "keys and audit events of a user share one pseudonym")
+		for _, key := range keys {
+			assert.NotContains(t, key.Name, "alice")
+			assert.Empty(t, key.Description)
+			assert.NotEqual(t, "prod", key.Labels["env"])
+		}
+		assert.Equal(t, keys[0].Labels, keys[1].Labels, "equal label values stay equal")
+
+		selected, _, err := store.List(ctx, pseudonym, api_keys.ListOptions{Selector: keys[0].Labels})
+		require.NoError(t, err)
+		assert.Len(t, selected, 2)
+	})
+
+	t.Run("IdempotencyRecordsDeleted", func(t *testing.T) {
+		existing, err := store.ReserveIdempotencyKey(ctx, "alice@example.com", "retry-1", "hash", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+	})
+}
+
+func TestStoreListOptions(t *testing.T) {
+	ctx := t.Context()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	now := time.Now()
+	for i, name := range []string{"beta", "Alpha build", "gamma_1", "alpha-ci"} {
+		require.NoError(t, store.Add(ctx, "user", &api_keys.APIKey{
+			Token: token.Token{
+				JTI:       name,
+				IssuedAt:  now.Add(time.Duration(i) * time.Minute).Unix(),
+				ExpiresAt: now.Add(time.Duration(10-i) * time.Hour).Unix(),
+			},
+			Name: name,
+		}))
+	}
+
+	names := func(keys []api_keys.ApiKeyMetadata) []string {
+		var out []string
+		for _, key := range keys {
+			out = append(out, key.Name)
+		}
+		return out
+	}
+
+	t.Run("DefaultNewestFirst", func(t *testing.T) {
+		keys, total, err := store.List(ctx, "user", api_keys.ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []string{"alpha-ci", "gamma_1", "Alpha build", "beta"}, names(keys))
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		keys, _, err := store.List(ctx, "user", api_keys.ListOptions{Sort: "expires"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alpha-ci", "gamma_1", "Alpha build", "beta"}, names(keys))
+
+		keys, _, err = store.List(ctx, "user", api_keys.ListOptions{Sort: "-name"})
+		require.NoError(t, err)
+		assert.Equal(t, "gamma_1", keys[0].Name)
+
+		_, _, err = store.List(ctx, "user", api_keys.ListOptions{Sort: "owner"})
+		require.ErrorIs(t, err, api_keys.ErrInvalidListOptions)
+	})
+
+	t.Run("Pages", func(t *testing.T) {
+		keys, total, err := store.List(ctx, "user", api_keys.ListOptions{Limit: 3, Offset: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total, "total counts every page")
+		assert.Equal(t, []string{"Alpha build", "beta"}, names(keys))
+
+		keys, _, err = store.List(ctx, "user", api_keys.ListOptions{Offset: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"beta"}, names(keys))
+	})
+
+	t.Run("NameFilter", func(t *testing.T) {
+		keys, total, err := store.List(ctx, "user", api_keys.ListOptions{Name: "ALPHA"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []string{"alpha-ci", "Alpha build"}, names(keys))
+
+		keys, _, err = store.List(ctx, "user", api_keys.ListOptions{Name: "a_"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gamma_1"}, names(keys), "wildcards match literally")
+	})
+}
+
+func TestStoreAddIdempotent(t *testing.T) {
+	ctx := t.Context()
+
+	store := createTestStore(t)
+	defer store.Close()
+
+	notBefore := time.Now().Add(-time.Hour)
+	existing, err := store.ReserveIdempotencyKey(ctx, "user", "retry-1", "hash", notBefore)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	require.NoError(t, store.AddIdempotent(ctx, "user", &api_keys.APIKey{
+		Token: token.Token{JTI: "jti-1", ExpiresAt: time.Now().Add(time.Hour).Unix()},
+		Name:  "key",
+	}, "retry-1"))
+
+	existing, err = store.ReserveIdempotencyKey(ctx, "user", "retry-1", "hash", notBefore)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Equal(t, "jti-1", existing.JTI, "storing the key completes the reservation")
+
+	t.Run("FailedInsertLeavesReservationPending", func(t *testing.T) {
+		_, err := store.ReserveIdempotencyKey(ctx, "user", "retry-2", "hash", notBefore)
+		require.NoError(t, err)
+
+		err = store.AddIdempotent(ctx, "user", &api_keys.APIKey{
+			Token: token.Token{JTI: "jti-2", ExpiresAt: time.Now().Add(time.Hour).Unix()},
+		}, "retry-2")
+		require.ErrorIs(t, err, api_keys.ErrEmptyName)
+
+		existing, err := store.ReserveIdempotencyKey(ctx, "user", "retry-2", "hash", notBefore)
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.Empty(t, existing.JTI)
+	})
+}
@@ -1,23 +1,38 @@
 package api_keys
 
-import "github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+import (
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+)
 
 // APIKey represents a full API key with token and metadata.
 // It embeds token.Token and adds API key-specific fields.
 type APIKey struct {
 	token.Token
 
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
 }
 
 // ApiKeyMetadata represents metadata for a single API key (without the token itself).
 // Used for listing and retrieving API key metadata from the database.
 type ApiKeyMetadata struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Description    string `json:"description,omitempty"`
-	CreationDate   string `json:"creationDate"`
-	ExpirationDate string `json:"expirationDate"`
-	Status         string `json:"status"` // "active", "expired"
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	CreationDate   types.Timestamp   `json:"creationDate"`
+	ExpirationDate types.Timestamp   `json:"expirationDate"`
+	Status         string            `json:"status"` // "active", "expired"
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// IdempotencyRecord tracks a POST /v1/api-keys request made with an Idempotency-Key header.
+// JTI is empty while the original request is still in flight.
+type IdempotencyRecord struct {
+	RequestHash string
+	JTI         string
+	CreatedAt   time.Time
 }
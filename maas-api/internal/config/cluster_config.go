@@ -1,13 +1,14 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	kserveclient "github.com/kserve/kserve/pkg/client/clientset/versioned"
 	kserveinformers "github.com/kserve/kserve/pkg/client/informers/externalversions"
 	kservelistersv1alpha1 "github.com/kserve/kserve/pkg/client/listers/serving/v1alpha1"
 	kservelistersv1beta1 "github.com/kserve/kserve/pkg/client/listers/serving/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -31,11 +32,16 @@ type ClusterConfig struct {
 
 	HTTPRouteLister gatewaylisters.HTTPRouteLister
 
-	informersSynced []cache.InformerSynced
-	startFuncs      []func(<-chan struct{})
+	configMapInformer cache.SharedIndexInformer
+	informersSynced   []cache.InformerSynced
+	startFuncs        []func(<-chan struct{})
 }
 
-func NewClusterConfig(namespace string, resyncPeriod time.Duration) (*ClusterConfig, error) {
+func NewClusterConfig(namespace string, opts InformerOptions) (*ClusterConfig, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	restConfig, err := LoadRestConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes config: %w", err)
@@ -56,45 +62,96 @@ func NewClusterConfig(namespace string, resyncPeriod time.Duration) (*ClusterCon
 		return nil, fmt.Errorf("failed to create Gateway API clientset: %w", err)
 	}
 
-	coreFactory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
+	resyncPeriod := opts.ResyncPeriod
+
+	coreFactory := informers.NewSharedInformerFactoryWithOptions(clientset, resyncPeriod,
+		informers.WithTweakListOptions(withLabelSelector(opts.TokenLabelSelector)))
 	coreFactoryNs := informers.NewSharedInformerFactoryWithOptions(clientset, resyncPeriod, informers.WithNamespace(namespace))
-	kserveFactory := kserveinformers.NewSharedInformerFactory(kserveClientset, resyncPeriod)
-	gatewayFactory := gatewayinformers.NewSharedInformerFactory(gatewayClientset, resyncPeriod)
 
 	cmInformer := coreFactoryNs.Core().V1().ConfigMaps()
 	nsInformer := coreFactory.Core().V1().Namespaces()
 	saInformer := coreFactory.Core().V1().ServiceAccounts()
-	isvcInformer := kserveFactory.Serving().V1beta1().InferenceServices()
-	llmIsvcInformer := kserveFactory.Serving().V1alpha1().LLMInferenceServices()
-	httpRouteInformer := gatewayFactory.Gateway().V1().HTTPRoutes()
 
-	return &ClusterConfig{
+	c := &ClusterConfig{
 		ClientSet: clientset,
 
 		ConfigMapLister:      cmInformer.Lister(),
 		NamespaceLister:      nsInformer.Lister(),
 		ServiceAccountLister: saInformer.Lister(),
 
-		InferenceServiceLister:    isvcInformer.Lister(),
-		LLMInferenceServiceLister: llmIsvcInformer.Lister(),
-
-		HTTPRouteLister: httpRouteInformer.Lister(),
-
+		configMapInformer: cmInformer.Informer(),
 		informersSynced: []cache.InformerSynced{
 			cmInformer.Informer().HasSynced,
 			nsInformer.Informer().HasSynced,
 			saInformer.Informer().HasSynced,
-			isvcInformer.Informer().HasSynced,
-			llmIsvcInformer.Informer().HasSynced,
-			httpRouteInformer.Informer().HasSynced,
 		},
 		startFuncs: []func(<-chan struct{}){
 			coreFactory.Start,
 			coreFactoryNs.Start,
-			kserveFactory.Start,
-			gatewayFactory.Start,
 		},
-	}, nil
+	}
+
+	// Model informers watch either all namespaces or one informer per allowlisted namespace,
+	// whose caches are merged so that listers see a single view.
+	modelNamespaces := opts.ModelNamespaces
+	if len(modelNamespaces) == 0 {
+		modelNamespaces = []string{metav1.NamespaceAll}
+	}
+
+	isvcIndexers := make(map[string]cache.Indexer, len(modelNamespaces))
+	llmIsvcIndexers := make(map[string]cache.Indexer, len(modelNamespaces))
+	httpRouteIndexers := make(map[string]cache.Indexer, len(modelNamespaces))
+
+	for _, ns := range modelNamespaces {
+		kserveFactory := kserveinformers.NewSharedInformerFactoryWithOptions(kserveClientset, resyncPeriod,
+			kserveinformers.WithNamespace(ns),
+			kserveinformers.WithTweakListOptions(withLabelSelector(opts.ModelLabelSelector)))
+		gatewayFactory := gatewayinformers.NewSharedInformerFactoryWithOptions(gatewayClientset, resyncPeriod,
+			gatewayinformers.WithNamespace(ns))
+
+		isvcInformer := kserveFactory.Serving().V1beta1().InferenceServices().Informer()
+		llmIsvcInformer := kserveFactory.Serving().V1alpha1().LLMInferenceServices().Informer()
+		httpRouteInformer := gatewayFactory.Gateway().V1().HTTPRoutes().Informer()
+
+		isvcIndexers[ns] = isvcInformer.GetIndexer()
+		llmIsvcIndexers[ns] = llmIsvcInformer.GetIndexer()
+		httpRouteIndexers[ns] = httpRouteInformer.GetIndexer()
+
+		c.informersSynced = append(c.informersSynced,
+			isvcInformer.HasSynced,
+			llmIsvcInformer.HasSynced,
+			httpRouteInformer.HasSynced,
+		)
+		c.startFuncs = append(c.startFuncs, kserveFactory.Start, gatewayFactory.Start)
+	}
+
+	c.InferenceServiceLister = kservelistersv1beta1.NewInferenceServiceLister(mergeIndexers(isvcIndexers))
+	c.LLMInferenceServiceLister = kservelistersv1alpha1.NewLLMInferenceServiceLister(mergeIndexers(llmIsvcIndexers))
+	c.HTTPRouteLister = gatewaylisters.NewHTTPRouteLister(mergeIndexers(httpRouteIndexers))
+
+	return c, nil
+}
+
+// mergeIndexers returns the cluster-wide indexer as is, or a merged view of per-namespace indexers.
+//
+//nolint:ireturn // Either indexer satisfies the generated lister constructors.
+func mergeIndexers(byNamespace map[string]cache.Indexer) cache.Indexer {
+	if idx, ok := byNamespace[metav1.NamespaceAll]; ok {
+		return idx
+	}
+	return newNamespacedIndexers(byNamespace)
+}
+
+func withLabelSelector(selector string) func(*metav1.ListOptions) {
+	return func(o *metav1.ListOptions) {
+		o.LabelSelector = selector
+	}
+}
+
+// AddConfigMapEventHandler subscribes to changes of ConfigMaps in the maas-api namespace.
+func (c *ClusterConfig) AddConfigMapEventHandler(handler cache.ResourceEventHandler) error {
+	_, err := c.configMapInformer.AddEventHandler(handler)
+	return err
 }
 
 func (c *ClusterConfig) StartAndWaitForSync(stopCh <-chan struct{}) bool {
@@ -104,6 +161,11 @@ func (c *ClusterConfig) StartAndWaitForSync(stopCh <-chan struct{}) bool {
 	return cache.WaitForCacheSync(stopCh, c.informersSynced...)
 }
 
+// Ping verifies the Kubernetes API server is reachable by requesting its version.
+func (c *ClusterConfig) Ping(ctx context.Context) error {
+	return c.ClientSet.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
 // LoadRestConfig creates a *rest.Config using client-go loading rules.
 // Order:
 // 1) KUBECONFIG or $HOME/.kube/config (if present and non-default)
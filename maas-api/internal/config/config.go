@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 
 	"k8s.io/utils/env"
 
@@ -62,25 +65,140 @@ type Config struct {
 	// DataPath is the path to the database file for disk mode.
 	// Default: /data/maas-api.db
 	DataPath string
+
+	// TrustedProxies lists the IPs/CIDRs of proxies (e.g. the gateway) whose
+	// X-Forwarded-For / X-Real-IP headers are honored when resolving the client IP.
+	// When empty, forwarded headers are ignored and the peer address is used.
+	TrustedProxies []string
+
+	// MaxKeysPerUser limits the number of active API keys a user may hold.
+	// Zero means unlimited.
+	MaxKeysPerUser int
+
+	// TokenIssueRatePerMinute and TokenIssueBurst limit how often a single user may obtain
+	// tokens and API keys. A non-positive rate disables the limit.
+	TokenIssueRatePerMinute int
+	TokenIssueBurst         int
+
+	// RevokeCooldown blocks issuance for a user after they revoke all tokens. Zero disables it.
+	RevokeCooldown time.Duration
+
+	// IdempotencyWindow is how long an Idempotency-Key sent with POST /v1/api-keys
+	// is remembered; retries within the window replay the original key instead of minting a new one.
+	IdempotencyWindow time.Duration
+
+	// RouteTimeout bounds read-only routes (models listing, tier lookup).
+	RouteTimeout time.Duration
+
+	// TokenRouteTimeout bounds token and API key routes, which may create
+	// namespaces, service accounts and tokens through the Kubernetes API.
+	TokenRouteTimeout time.Duration
+
+	// InformerResyncPeriod is how often informer caches are replayed to event handlers.
+	InformerResyncPeriod time.Duration
+
+	// ModelNamespaces restricts model discovery (InferenceService, LLMInferenceService, HTTPRoute
+	// informers) to these namespaces. Empty watches all namespaces.
+	ModelNamespaces []string
+
+	// ModelLabelSelector filters the InferenceService and LLMInferenceService objects that are cached.
+	ModelLabelSelector string
+
+	// TokenLabelSelector filters the Namespace and ServiceAccount objects that are cached
+	// for token issuance.
+	TokenLabelSelector string
+
+	// MetricsPort is the port serving Prometheus metrics on /metrics. It is kept separate
+	// from Port so that metrics are not exposed through the gateway route. Empty disables it.
+	MetricsPort string
+
+	// DeprecatedRoutes is a JSON array describing routes that should carry
+	// Deprecation/Sunset headers. See middleware.DeprecatedRoute for the format.
+	DeprecatedRoutes string
+
+	// WebhookURL receives signed API key lifecycle events (creation, revocation). Empty disables them.
+	WebhookURL string
+
+	// WebhookSecret is the HMAC key used to sign webhook deliveries. It is only read from
+	// the environment so that it does not appear in the process arguments.
+	WebhookSecret string
+
+	// ExpiryReminderWindow sends an api_key.expiring webhook digest to each user with keys expiring
+	// within this window. Zero disables reminders; they require WebhookURL.
+	ExpiryReminderWindow time.Duration
+
+	// ExpiryReminderInterval is how often keys are checked for expiry reminders.
+	ExpiryReminderInterval time.Duration
+
+	// AnonymizeTarget is a postgresql:// URL or SQLite file path of a database copy. When set, personal
+	// data in that copy is pseudonymized and the process exits instead of serving. It must differ from
+	// DBConnectionURL and DataPath so that the serving database is never rewritten.
+	AnonymizeTarget string
+
+	// FaultInjection enables the X-MaaS-Fault request header and FaultSpec, which inject database
+	// latency and errors to exercise resilience behavior. It requires DebugMode.
+	FaultInjection bool
+
+	// FaultSpec lists faults applied to every request, in the X-MaaS-Fault format (e.g. "db-latency=200ms").
+	FaultSpec string
+
+	// envErrors collects environment variables that could not be parsed; see Validate.
+	envErrors []error
 }
 
 // Load loads configuration from environment variables.
 func Load() *Config {
 	debugMode, _ := env.GetBool("DEBUG_MODE", false)
+	faultInjection, _ := env.GetBool("FAULT_INJECTION", false)
+	maxKeysPerUser, _ := env.GetInt("MAX_KEYS_PER_USER", 0)
+	tokenIssueRate, _ := env.GetInt("TOKEN_ISSUE_RATE_PER_MINUTE", constant.DefaultTokenIssueRatePerMinute)
+	tokenIssueBurst, _ := env.GetInt("TOKEN_ISSUE_BURST", constant.DefaultTokenIssueBurst)
 	gatewayName := env.GetString("GATEWAY_NAME", constant.DefaultGatewayName)
 
+	var envErrors []error
+	getDuration := func(key string, def time.Duration) time.Duration {
+		d, err := parseDurationEnv(key, def)
+		if err != nil {
+			envErrors = append(envErrors, err)
+		}
+		return d
+	}
+
 	c := &Config{
-		Name:             env.GetString("INSTANCE_NAME", gatewayName),
-		Namespace:        env.GetString("NAMESPACE", constant.DefaultNamespace),
-		GatewayName:      env.GetString("GATEWAY_NAME", gatewayName),
-		GatewayNamespace: env.GetString("GATEWAY_NAMESPACE", constant.DefaultGatewayNamespace),
-		Port:             env.GetString("PORT", "8080"),
-		DebugMode:        debugMode,
-		StorageMode:      StorageModeInMemory,
-		DBConnectionURL:  env.GetString("DB_CONNECTION_URL", ""),
-		DataPath:         env.GetString("DATA_PATH", DefaultDataPath),
+		Name:                    env.GetString("INSTANCE_NAME", gatewayName),
+		Namespace:               env.GetString("NAMESPACE", constant.DefaultNamespace),
+		GatewayName:             env.GetString("GATEWAY_NAME", gatewayName),
+		GatewayNamespace:        env.GetString("GATEWAY_NAMESPACE", constant.DefaultGatewayNamespace),
+		Port:                    env.GetString("PORT", "8080"),
+		DebugMode:               debugMode,
+		StorageMode:             StorageModeInMemory,
+		DBConnectionURL:         env.GetString("DB_CONNECTION_URL", ""),
+		DataPath:                env.GetString("DATA_PATH", DefaultDataPath),
+		TrustedProxies:          splitList(env.GetString("TRUSTED_PROXIES", "")),
+		MaxKeysPerUser:          maxKeysPerUser,
+		TokenIssueRatePerMinute: tokenIssueRate,
+		TokenIssueBurst:         tokenIssueBurst,
+		RevokeCooldown:          getDuration("REVOKE_COOLDOWN", constant.DefaultRevokeCooldown),
+		IdempotencyWindow:       getDuration("IDEMPOTENCY_WINDOW", constant.DefaultIdempotencyWindow),
+		RouteTimeout:            getDuration("ROUTE_TIMEOUT", constant.DefaultRouteTimeout),
+		TokenRouteTimeout:       getDuration("TOKEN_ROUTE_TIMEOUT", constant.DefaultTokenRouteTimeout),
+		DeprecatedRoutes:        env.GetString("DEPRECATED_ROUTES", ""),
+		MetricsPort:             env.GetString("METRICS_PORT", "9090"),
+		WebhookURL:              env.GetString("WEBHOOK_URL", ""),
+		WebhookSecret:           env.GetString("WEBHOOK_SECRET", ""),
+		ExpiryReminderWindow:    getDuration("EXPIRY_REMINDER_WINDOW", 0),
+		ExpiryReminderInterval:  getDuration("EXPIRY_REMINDER_INTERVAL", constant.DefaultExpiryReminderInterval),
+		FaultInjection:          faultInjection,
+		FaultSpec:               env.GetString("FAULT_SPEC", ""),
+
+		InformerResyncPeriod: getDuration("INFORMER_RESYNC_PERIOD", constant.DefaultResyncPeriod),
+		ModelNamespaces:      splitList(env.GetString("MODEL_NAMESPACES", "")),
+		ModelLabelSelector:   env.GetString("MODEL_LABEL_SELECTOR", ""),
+		TokenLabelSelector:   env.GetString("TOKEN_LABEL_SELECTOR", ""),
 	}
 
+	c.envErrors = envErrors
+
 	// Validate STORAGE_MODE env var through Set() to ensure consistent validation
 	if err := c.StorageMode.Set(env.GetString("STORAGE_MODE", "")); err != nil {
 		// Log warning and fall back to default (in-memory)
@@ -103,4 +221,61 @@ func (c *Config) bindFlags(fs *flag.FlagSet) {
 	fs.Var(&c.StorageMode, "storage", "Storage mode: in-memory (default), disk, or external")
 	fs.StringVar(&c.DBConnectionURL, "db-connection-url", c.DBConnectionURL, "Database connection URL (required for --storage=external)")
 	fs.StringVar(&c.DataPath, "data-path", c.DataPath, "Path to database file (for --storage=disk)")
+	fs.Func("trusted-proxies", "Comma-separated IPs/CIDRs of proxies trusted to set X-Forwarded-For", func(value string) error {
+		c.TrustedProxies = splitList(value)
+		return nil
+	})
+	fs.IntVar(&c.MaxKeysPerUser, "max-keys-per-user", c.MaxKeysPerUser, "Maximum number of active API keys per user (0 means unlimited)")
+	fs.IntVar(&c.TokenIssueRatePerMinute, "token-issue-rate", c.TokenIssueRatePerMinute, "Tokens and API keys a user may obtain per minute (0 disables)")
+	fs.IntVar(&c.TokenIssueBurst, "token-issue-burst", c.TokenIssueBurst, "Burst allowance for --token-issue-rate")
+	fs.DurationVar(&c.RevokeCooldown, "revoke-cooldown", c.RevokeCooldown, "How long issuance is blocked for a user after revoking all tokens (0 disables)")
+	fs.DurationVar(&c.IdempotencyWindow, "idempotency-window", c.IdempotencyWindow, "How long Idempotency-Key values for API key creation are remembered")
+	fs.DurationVar(&c.RouteTimeout, "route-timeout", c.RouteTimeout, "Request deadline for read-only routes (0 disables)")
+	fs.DurationVar(&c.TokenRouteTimeout, "token-route-timeout", c.TokenRouteTimeout, "Request deadline for token and API key routes (0 disables)")
+	fs.DurationVar(&c.InformerResyncPeriod, "informer-resync-period", c.InformerResyncPeriod, "How often informer caches are resynced")
+	fs.Func("model-namespaces", "Comma-separated namespaces to discover models in (default: all namespaces)", func(value string) error {
+		c.ModelNamespaces = splitList(value)
+		return nil
+	})
+	fs.StringVar(&c.ModelLabelSelector, "model-label-selector", c.ModelLabelSelector, "Label selector for cached InferenceService and LLMInferenceService objects")
+	fs.StringVar(&c.TokenLabelSelector, "token-label-selector", c.TokenLabelSelector, "Label selector for cached Namespace and ServiceAccount objects")
+	fs.StringVar(&c.MetricsPort, "metrics-port", c.MetricsPort, "Port to serve Prometheus metrics on (empty disables)")
+	fs.StringVar(&c.DeprecatedRoutes, "deprecated-routes", c.DeprecatedRoutes, "JSON array of routes to mark with Deprecation/Sunset headers")
+	fs.StringVar(&c.AnonymizeTarget, "anonymize-target", "", "Database copy (postgresql:// URL or SQLite path) whose users, key names and labels are pseudonymized, then exit; must differ from the serving database")
+	fs.BoolVar(&c.FaultInjection, "fault-injection", c.FaultInjection, "Enable fault injection through the X-MaaS-Fault header (requires --debug, never use in production)")
+	fs.StringVar(&c.FaultSpec, "fault-spec", c.FaultSpec, "Faults injected into every request when --fault-injection is set, e.g. db-latency=200ms,db-error")
+	fs.StringVar(&c.WebhookURL, "webhook-url", c.WebhookURL, "Endpoint receiving signed API key lifecycle events (empty disables)")
+	fs.DurationVar(&c.ExpiryReminderWindow, "expiry-reminder-window", c.ExpiryReminderWindow, "Send users a webhook digest of keys expiring within this window (0 disables, requires --webhook-url)")
+	fs.DurationVar(&c.ExpiryReminderInterval, "expiry-reminder-interval", c.ExpiryReminderInterval, "How often keys are checked for expiry reminders")
+}
+
+// splitList splits a comma-separated value, dropping blank entries.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate reports environment variables that could not be parsed. Like an invalid flag,
+// an invalid value must stop startup rather than silently fall back to the default.
+func (c *Config) Validate() error {
+	return errors.Join(c.envErrors...)
+}
+
+// parseDurationEnv reads a Go duration string from the environment, returning def when the
+// variable is unset and an error when it is not a valid duration (e.g. "10" without a unit).
+func parseDurationEnv(key string, def time.Duration) (time.Duration, error) {
+	value := env.GetString(key, "")
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return def, fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	return d, nil
 }
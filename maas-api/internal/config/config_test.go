@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDurationEnv(t *testing.T) {
+	t.Run("unset uses the default", func(t *testing.T) {
+		t.Setenv("ROUTE_TIMEOUT", "")
+		d, err := parseDurationEnv("ROUTE_TIMEOUT", 30*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, d)
+	})
+
+	t.Run("valid duration", func(t *testing.T) {
+		t.Setenv("ROUTE_TIMEOUT", "45s")
+		d, err := parseDurationEnv("ROUTE_TIMEOUT", 30*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 45*time.Second, d)
+	})
+
+	t.Run("missing unit is rejected", func(t *testing.T) {
+		t.Setenv("TOKEN_ROUTE_TIMEOUT", "10")
+		_, err := parseDurationEnv("TOKEN_ROUTE_TIMEOUT", 30*time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TOKEN_ROUTE_TIMEOUT")
+	})
+}
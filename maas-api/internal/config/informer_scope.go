@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+var errReadOnlyIndexer = errors.New("namespaced indexer is read-only")
+
+// InformerOptions scopes the informer caches to reduce memory and watch load on large clusters.
+type InformerOptions struct {
+	// ResyncPeriod is how often informers replay their cache to event handlers.
+	ResyncPeriod time.Duration
+	// ModelNamespaces restricts the InferenceService, LLMInferenceService and HTTPRoute
+	// informers to these namespaces. Empty watches all namespaces.
+	ModelNamespaces []string
+	// ModelLabelSelector filters InferenceService and LLMInferenceService objects.
+	ModelLabelSelector string
+	// TokenLabelSelector filters the Namespace and ServiceAccount objects used for token issuance,
+	// e.g. "maas.opendatahub.io/instance=maas-default-gateway" to cache only what this instance created.
+	TokenLabelSelector string
+}
+
+func (o InformerOptions) validate() error {
+	if _, err := labels.Parse(o.ModelLabelSelector); err != nil {
+		return fmt.Errorf("invalid model label selector %q: %w", o.ModelLabelSelector, err)
+	}
+	if _, err := labels.Parse(o.TokenLabelSelector); err != nil {
+		return fmt.Errorf("invalid token label selector %q: %w", o.TokenLabelSelector, err)
+	}
+	return nil
+}
+
+// namespacedIndexers presents the caches of several single-namespace informers as one
+// read-only indexer, so generated listers can serve a namespace allowlist as if it were
+// a single cluster-wide cache.
+type namespacedIndexers struct {
+	byNamespace map[string]cache.Indexer
+}
+
+var _ cache.Indexer = (*namespacedIndexers)(nil)
+
+func newNamespacedIndexers(byNamespace map[string]cache.Indexer) *namespacedIndexers {
+	return &namespacedIndexers{byNamespace: byNamespace}
+}
+
+func (n *namespacedIndexers) List() []any {
+	var items []any
+	for _, idx := range n.byNamespace {
+		items = append(items, idx.List()...)
+	}
+	return items
+}
+
+func (n *namespacedIndexers) ListKeys() []string {
+	var keys []string
+	for _, idx := range n.byNamespace {
+		keys = append(keys, idx.ListKeys()...)
+	}
+	return keys
+}
+
+func (n *namespacedIndexers) Get(obj any) (any, bool, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return n.GetByKey(key)
+}
+
+func (n *namespacedIndexers) GetByKey(key string) (any, bool, error) {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	idx, ok := n.byNamespace[namespace]
+	if !ok {
+		return nil, false, nil
+	}
+	return idx.GetByKey(key)
+}
+
+func (n *namespacedIndexers) Index(indexName string, obj any) ([]any, error) {
+	if indexName == cache.NamespaceIndex {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		idx, ok := n.byNamespace[accessor.GetNamespace()]
+		if !ok {
+			return []any{}, nil
+		}
+		return idx.Index(indexName, obj)
+	}
+
+	var items []any
+	for _, idx := range n.byNamespace {
+		found, err := idx.Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+func (n *namespacedIndexers) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	if indexName == cache.NamespaceIndex {
+		idx, ok := n.byNamespace[indexedValue]
+		if !ok {
+			return []string{}, nil
+		}
+		return idx.IndexKeys(indexName, indexedValue)
+	}
+
+	var keys []string
+	for _, idx := range n.byNamespace {
+		found, err := idx.IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, found...)
+	}
+	return keys, nil
+}
+
+func (n *namespacedIndexers) ByIndex(indexName, indexedValue string) ([]any, error) {
+	if indexName == cache.NamespaceIndex {
+		idx, ok := n.byNamespace[indexedValue]
+		if !ok {
+			return []any{}, nil
+		}
+		return idx.ByIndex(indexName, indexedValue)
+	}
+
+	var items []any
+	for _, idx := range n.byNamespace {
+		found, err := idx.ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+func (n *namespacedIndexers) ListIndexFuncValues(indexName string) []string {
+	values := sets.New[string]()
+	for _, idx := range n.byNamespace {
+		values.Insert(idx.ListIndexFuncValues(indexName)...)
+	}
+	return sets.List(values)
+}
+
+func (n *namespacedIndexers) GetIndexers() cache.Indexers {
+	// All informers are created with the same indexers, so any one of them is representative.
+	namespaces := slices.Sorted(maps.Keys(n.byNamespace))
+	if len(namespaces) == 0 {
+		return cache.Indexers{}
+	}
+	return n.byNamespace[namespaces[0]].GetIndexers()
+}
+
+func (n *namespacedIndexers) Add(any) error                    { return errReadOnlyIndexer }
+func (n *namespacedIndexers) Update(any) error                 { return errReadOnlyIndexer }
+func (n *namespacedIndexers) Delete(any) error                 { return errReadOnlyIndexer }
+func (n *namespacedIndexers) Replace([]any, string) error      { return errReadOnlyIndexer }
+func (n *namespacedIndexers) Resync() error                    { return errReadOnlyIndexer }
+func (n *namespacedIndexers) AddIndexers(cache.Indexers) error { return errReadOnlyIndexer }
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewaylisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1"
+)
+
+func TestNamespacedIndexers(t *testing.T) {
+	route := func(namespace, name string) *gatewayapiv1.HTTPRoute {
+		return &gatewayapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	namespaceIndexer := func(t *testing.T, routes ...*gatewayapiv1.HTTPRoute) cache.Indexer {
+		t.Helper()
+		idx := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for _, r := range routes {
+			require.NoError(t, idx.Add(r))
+		}
+		return idx
+	}
+	names := func(routes []*gatewayapiv1.HTTPRoute) []string {
+		var out []string
+		for _, r := range routes {
+			out = append(out, r.Namespace+"/"+r.Name)
+		}
+		return out
+	}
+
+	merged := newNamespacedIndexers(map[string]cache.Indexer{
+		"models-a": namespaceIndexer(t, route("models-a", "llama"), route("models-a", "granite")),
+		"models-b": namespaceIndexer(t, route("models-b", "mistral")),
+	})
+	lister := gatewaylisters.NewHTTPRouteLister(merged)
+
+	t.Run("List", func(t *testing.T) {
+		routes, err := lister.List(labels.Everything())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"models-a/llama", "models-a/granite", "models-b/mistral"}, names(routes))
+		assert.Len(t, merged.ListKeys(), 3)
+	})
+
+	t.Run("GetByKey", func(t *testing.T) {
+		obj, exists, err := merged.GetByKey("models-b/mistral")
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, "mistral", obj.(*gatewayapiv1.HTTPRoute).Name)
+
+		_, exists, err = merged.GetByKey("models-b/llama")
+		require.NoError(t, err)
+		assert.False(t, exists, "key from another allowlisted namespace")
+
+		_, exists, err = merged.GetByKey("other/llama")
+		require.NoError(t, err)
+		assert.False(t, exists, "namespace outside the allowlist")
+	})
+
+	t.Run("NamespaceLister", func(t *testing.T) {
+		routes, err := lister.HTTPRoutes("models-a").List(labels.Everything())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"models-a/llama", "models-a/granite"}, names(routes))
+
+		r, err := lister.HTTPRoutes("models-b").Get("mistral")
+		require.NoError(t, err)
+		assert.Equal(t, "mistral", r.Name)
+
+		routes, err = lister.HTTPRoutes("other").List(labels.Everything())
+		require.NoError(t, err)
+		assert.Empty(t, routes)
+
+		_, err = lister.HTTPRoutes("other").Get("llama")
+		require.Error(t, err)
+	})
+
+	t.Run("ByIndex", func(t *testing.T) {
+		items, err := merged.ByIndex(cache.NamespaceIndex, "models-b")
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		items, err = merged.ByIndex(cache.NamespaceIndex, "other")
+		require.NoError(t, err)
+		assert.Empty(t, items)
+
+		assert.ElementsMatch(t, []string{"models-a", "models-b"}, merged.ListIndexFuncValues(cache.NamespaceIndex))
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		require.ErrorIs(t, merged.Add(route("models-a", "new")), errReadOnlyIndexer)
+	})
+}
@@ -10,6 +10,21 @@ const (
 
 	DefaultResyncPeriod = 8 * time.Hour
 
+	// Request deadlines; both must stay below the server WriteTimeout.
+	DefaultRouteTimeout      = 10 * time.Second
+	DefaultTokenRouteTimeout = 25 * time.Second
+
+	// Per-user token and API key issuance limits.
+	DefaultTokenIssueRatePerMinute = 30
+	DefaultTokenIssueBurst         = 10
+	DefaultRevokeCooldown          = 10 * time.Second
+
+	// DefaultIdempotencyWindow is how long an Idempotency-Key on POST /v1/api-keys is remembered.
+	DefaultIdempotencyWindow = 24 * time.Hour
+
+	// DefaultExpiryReminderInterval is how often keys are checked for expiry reminders.
+	DefaultExpiryReminderInterval = time.Hour
+
 	// Header configuration constants.
 	HeaderUsername = "X-MaaS-Username"
 	HeaderGroup    = "X-MaaS-Group"
@@ -0,0 +1,139 @@
+package errcode
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Code is a stable, machine-readable error identifier returned alongside the
+// human-readable error text. Clients (e.g. the portal) should key translations
+// on the code; the text is the default English message and may change.
+type Code string
+
+const (
+	InvalidRequest     Code = "INVALID_REQUEST"
+	InvalidExpiration  Code = "INVALID_EXPIRATION"
+	RequestTimeout     Code = "REQUEST_TIMEOUT"
+	ResponseFailed     Code = "RESPONSE_FAILED"
+	ServiceStarting    Code = "SERVICE_STARTING"
+	StorageUnavailable Code = "STORAGE_UNAVAILABLE"
+
+	AuthFailure        Code = "AUTH_FAILURE"
+	UserContextMissing Code = "USER_CONTEXT_MISSING"
+	UserContextInvalid Code = "USER_CONTEXT_INVALID"
+
+	TokenIssueFailed  Code = "TOKEN_ISSUE_FAILED"
+	TokenRevokeFailed Code = "TOKEN_REVOKE_FAILED"
+
+	TokenRateLimited    Code = "TOKEN_RATE_LIMITED"
+	TokenRevokeCooldown Code = "TOKEN_REVOKE_COOLDOWN"
+	TokenAuditForbidden Code = "TOKEN_AUDIT_FORBIDDEN"
+	TokenAuditFailed    Code = "TOKEN_AUDIT_FAILED"
+
+	APIKeyNameRequired  Code = "API_KEY_NAME_REQUIRED"
+	APIKeyIDRequired    Code = "API_KEY_ID_REQUIRED"
+	APIKeyNotFound      Code = "API_KEY_NOT_FOUND"
+	APIKeyCreateFailed  Code = "API_KEY_CREATE_FAILED"
+	APIKeyListFailed    Code = "API_KEY_LIST_FAILED"
+	APIKeyGetFailed     Code = "API_KEY_GET_FAILED"
+	APIKeyQuotaExceeded Code = "API_KEY_QUOTA_EXCEEDED"
+	APIKeyLabelsInvalid Code = "API_KEY_LABELS_INVALID"
+	APIKeyUpdateFailed  Code = "API_KEY_UPDATE_FAILED"
+
+	IdempotencyKeyInvalid    Code = "IDEMPOTENCY_KEY_INVALID"
+	IdempotencyKeyReused     Code = "IDEMPOTENCY_KEY_REUSED"
+	IdempotencyKeyInProgress Code = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	IdempotencyKeyReplayed   Code = "IDEMPOTENCY_KEY_REPLAYED"
+
+	ModelsListFailed Code = "MODELS_LIST_FAILED"
+
+	TierNotFound     Code = "TIER_NOT_FOUND"
+	TierLookupFailed Code = "TIER_LOOKUP_FAILED"
+
+	InvalidRateLimit    Code = "INVALID_RATE_LIMIT"
+	RateLimitSpecFailed Code = "RATE_LIMIT_SPEC_FAILED"
+)
+
+// catalog holds the default English text for every code.
+var catalog = map[Code]string{
+	InvalidRequest:     "Invalid request",
+	InvalidExpiration:  "Invalid expiration",
+	RequestTimeout:     "Request timed out",
+	ResponseFailed:     "Failed to build response",
+	ServiceStarting:    "Service is starting",
+	StorageUnavailable: "Storage is unavailable, retry later",
+
+	AuthFailure:        "Exception thrown while generating token",
+	UserContextMissing: "User context not found",
+	UserContextInvalid: "Invalid user context type",
+
+	TokenIssueFailed:  "Failed to generate token",
+	TokenRevokeFailed: "Failed to revoke tokens",
+
+	TokenRateLimited:    "Too many token requests, retry later",
+	TokenRevokeCooldown: "Tokens were recently revoked, retry later",
+	TokenAuditForbidden: "Not allowed to view token audit for this namespace",
+	TokenAuditFailed:    "Failed to retrieve token audit",
+
+	APIKeyNameRequired:  "token name is required for api keys",
+	APIKeyIDRequired:    "Token ID required",
+	APIKeyNotFound:      "API key not found",
+	APIKeyCreateFailed:  "Failed to generate api key",
+	APIKeyListFailed:    "Failed to list api keys",
+	APIKeyGetFailed:     "Failed to retrieve API key",
+	APIKeyQuotaExceeded: "Maximum number of active api keys reached",
+	APIKeyLabelsInvalid: "Invalid api key labels",
+	APIKeyUpdateFailed:  "Failed to update api key",
+
+	IdempotencyKeyInvalid:    "Idempotency-Key must be 1 to 255 characters",
+	IdempotencyKeyReused:     "Idempotency-Key was already used with a different request",
+	IdempotencyKeyInProgress: "A request with this Idempotency-Key is still in progress",
+	IdempotencyKeyReplayed:   "The key for this Idempotency-Key was already created; its token cannot be returned again",
+
+	ModelsListFailed: "Failed to retrieve models",
+
+	TierNotFound:     "Tier not found",
+	TierLookupFailed: "Failed to look up tier",
+
+	InvalidRateLimit:    "Invalid rate limit",
+	RateLimitSpecFailed: "Failed to build rate limit spec",
+}
+
+// Message returns the default English text for the code, or the code itself if it is not in the catalog.
+func (c Code) Message() string {
+	if msg, ok := catalog[c]; ok {
+		return msg
+	}
+	return string(c)
+}
+
+// Response returns the standard error body: {"error": <default text>, "code": <code>}.
+func (c Code) Response() gin.H {
+	return gin.H{"error": c.Message(), "code": string(c)}
+}
+
+// ResponseWithDetail returns the standard error body using detail as the error text,
+// for errors whose text is produced at runtime (e.g. request validation).
+func (c Code) ResponseWithDetail(detail string) gin.H {
+	return gin.H{"error": detail, "code": string(c)}
+}
+
+// Failure returns the status and body for an unexpected error reported under code. An error caused
+// by the request deadline (see middleware.Timeout) is reported as 504 with RequestTimeout instead of 500,
+// so that clients can tell a spent route budget from a server fault.
+func (c Code) Failure(err error) (int, gin.H) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout, RequestTimeout.Response()
+	}
+	return http.StatusInternalServerError, c.Response()
+}
+
+// Catalog returns a copy of the code to default English text mapping,
+// suitable for seeding translation files.
+func Catalog() map[Code]string {
+	return maps.Clone(catalog)
+}
@@ -0,0 +1,44 @@
+package errcode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := errcode.Catalog()
+	assert.NotEmpty(t, catalog)
+
+	for code, msg := range catalog {
+		assert.NotEmpty(t, code, "catalog contains an empty code")
+		assert.NotEmpty(t, msg, "code %q has no default message", code)
+	}
+
+	// Mutating the returned copy must not affect the catalog.
+	catalog[errcode.APIKeyNotFound] = "changed"
+	assert.Equal(t, "API key not found", errcode.APIKeyNotFound.Message())
+}
+
+func TestResponse(t *testing.T) {
+	assert.Equal(t, gin.H{"error": "API key not found", "code": "API_KEY_NOT_FOUND"}, errcode.APIKeyNotFound.Response())
+	assert.Equal(t, gin.H{"error": "bad json", "code": "INVALID_REQUEST"}, errcode.InvalidRequest.ResponseWithDetail("bad json"))
+	assert.Equal(t, "UNKNOWN_CODE", errcode.Code("UNKNOWN_CODE").Message())
+}
+
+func TestFailure(t *testing.T) {
+	status, body := errcode.APIKeyListFailed.Failure(errors.New("connection reset"))
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "API_KEY_LIST_FAILED", body["code"])
+
+	status, body = errcode.APIKeyListFailed.Failure(fmt.Errorf("failed to list: %w", context.DeadlineExceeded))
+	assert.Equal(t, http.StatusGatewayTimeout, status)
+	assert.Equal(t, "REQUEST_TIMEOUT", body["code"])
+}
@@ -0,0 +1,88 @@
+// Package faults injects artificial failures into dependencies so that resilience behavior
+// (retries, timeouts, degraded modes) can be exercised in development and integration tests.
+// It must never be enabled in production.
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Header carries per-request faults in the same format as Parse, e.g. "db-latency=500ms,db-error".
+const Header = "X-MaaS-Fault"
+
+// ErrInjected is returned by dependencies failing because of an injected fault.
+var ErrInjected = errors.New("injected fault")
+
+// Faults describes the failures to inject into a request's dependency calls.
+type Faults struct {
+	// DBLatency delays every metadata store call.
+	DBLatency time.Duration
+	// DBError makes every metadata store call fail with ErrInjected, after DBLatency.
+	DBError bool
+}
+
+// Parse reads a comma-separated fault list. Supported entries are "db-latency=<duration>"
+// and "db-error". An empty spec injects nothing.
+func Parse(spec string) (Faults, error) {
+	var f Faults
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(entry, "=")
+		switch name {
+		case "db-latency":
+			latency, err := time.ParseDuration(value)
+			if !hasValue || err != nil || latency < 0 {
+				return Faults{}, fmt.Errorf("invalid fault %q: db-latency needs a non-negative duration", entry)
+			}
+			f.DBLatency = latency
+		case "db-error":
+			if hasValue {
+				return Faults{}, fmt.Errorf("invalid fault %q: db-error takes no value", entry)
+			}
+			f.DBError = true
+		default:
+			return Faults{}, fmt.Errorf("unknown fault %q", name)
+		}
+	}
+	return f, nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying f.
+func NewContext(ctx context.Context, f Faults) context.Context {
+	return context.WithValue(ctx, contextKey{}, f)
+}
+
+// FromContext returns the faults carried by ctx, if any.
+func FromContext(ctx context.Context) Faults {
+	f, _ := ctx.Value(contextKey{}).(Faults)
+	return f
+}
+
+// BeforeDB applies the database faults in ctx: it waits for DBLatency, or until ctx is done,
+// and then returns ErrInjected if DBError is set.
+func BeforeDB(ctx context.Context) error {
+	f := FromContext(ctx)
+	if f.DBLatency > 0 {
+		timer := time.NewTimer(f.DBLatency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if f.DBError {
+		return fmt.Errorf("%w: database error", ErrInjected)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package faults_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/faults"
+)
+
+func TestParse(t *testing.T) {
+	f, err := faults.Parse(" db-latency=250ms, db-error ")
+	require.NoError(t, err)
+	assert.Equal(t, faults.Faults{DBLatency: 250 * time.Millisecond, DBError: true}, f)
+
+	f, err = faults.Parse("")
+	require.NoError(t, err)
+	assert.Equal(t, faults.Faults{}, f)
+
+	for _, spec := range []string{"db-latency", "db-latency=-1s", "db-latency=soon", "db-error=true", "kube-error"} {
+		_, err := faults.Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestBeforeDB(t *testing.T) {
+	t.Run("NoFaults", func(t *testing.T) {
+		require.NoError(t, faults.BeforeDB(t.Context()))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ctx := faults.NewContext(t.Context(), faults.Faults{DBError: true})
+		require.ErrorIs(t, faults.BeforeDB(ctx), faults.ErrInjected)
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		ctx := faults.NewContext(t.Context(), faults.Faults{DBLatency: 20 * time.Millisecond})
+		start := time.Now()
+		require.NoError(t, faults.BeforeDB(ctx))
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("LatencyHonorsDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		ctx = faults.NewContext(ctx, faults.Faults{DBLatency: time.Minute})
+		require.ErrorIs(t, faults.BeforeDB(ctx), context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,73 @@
+package fields
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryParam is the query parameter clients use to select response fields,
+// e.g. GET /v1/api-keys?fields=id,name,status.
+const QueryParam = "fields"
+
+// FromQuery returns the field names requested through the fields query parameter.
+// It returns nil when the parameter is absent or empty, meaning "all fields".
+func FromQuery(c *gin.Context) []string {
+	raw := c.Query(QueryParam)
+	if raw == "" {
+		return nil
+	}
+
+	var selected []string
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			selected = append(selected, f)
+		}
+	}
+	return selected
+}
+
+// Project reduces v to the selected top-level JSON fields.
+// v must marshal to a JSON object or an array of objects; arrays are projected element-wise.
+// Unknown field names are ignored. When selected is empty, v is returned unchanged.
+func Project(v any, selected []string) (any, error) {
+	if len(selected) == 0 {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value for projection: %w", err)
+	}
+
+	keep := make(map[string]struct{}, len(selected))
+	for _, f := range selected {
+		keep[f] = struct{}{}
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err == nil {
+		for i := range items {
+			items[i] = pick(items[i], keep)
+		}
+		return items, nil
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("value is neither a JSON object nor an array of objects: %w", err)
+	}
+	return pick(item, keep), nil
+}
+
+func pick(item map[string]json.RawMessage, keep map[string]struct{}) map[string]json.RawMessage {
+	projected := make(map[string]json.RawMessage, len(keep))
+	for k, v := range item {
+		if _, ok := keep[k]; ok {
+			projected[k] = v
+		}
+	}
+	return projected
+}
@@ -0,0 +1,85 @@
+package fields_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/fields"
+)
+
+type item struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+func TestFromQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		url      string
+		expected []string
+	}{
+		{name: "absent", url: "/", expected: nil},
+		{name: "empty", url: "/?fields=", expected: nil},
+		{name: "single", url: "/?fields=id", expected: []string{"id"}},
+		{name: "trims and skips blanks", url: "/?fields=id,%20name,,", expected: []string{"id", "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequestWithContext(t.Context(), http.MethodGet, tt.url, nil)
+			assert.Equal(t, tt.expected, fields.FromQuery(c))
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	t.Run("NoSelectionReturnsValue", func(t *testing.T) {
+		in := item{ID: "1", Name: "a"}
+		out, err := fields.Project(in, nil)
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+	})
+
+	t.Run("Object", func(t *testing.T) {
+		out, err := fields.Project(item{ID: "1", Name: "a", Description: "d"}, []string{"id", "unknown"})
+		require.NoError(t, err)
+
+		data, err := json.Marshal(out)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"1"}`, string(data))
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		in := []item{{ID: "1", Name: "a"}, {ID: "2", Name: "b", Description: "d"}}
+		out, err := fields.Project(in, []string{"name", "description"})
+		require.NoError(t, err)
+
+		data, err := json.Marshal(out)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"name":"a"},{"name":"b","description":"d"}]`, string(data))
+	})
+
+	t.Run("EmptyArray", func(t *testing.T) {
+		out, err := fields.Project([]item{}, []string{"id"})
+		require.NoError(t, err)
+
+		data, err := json.Marshal(out)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[]`, string(data))
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		_, err := fields.Project("not-an-object", []string{"id"})
+		require.Error(t, err)
+	})
+}
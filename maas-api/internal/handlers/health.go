@@ -1,20 +1,137 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+const (
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+	StatusReady     = "ready"
+	StatusNotReady  = "not_ready"
+
+	ReasonTimeout     = "timeout"
+	ReasonUnavailable = "unavailable"
+
+	defaultCheckTimeout = 2 * time.Second
 )
 
+// DependencyCheck probes a single dependency of the service.
+type DependencyCheck struct {
+	// Name identifies the dependency in the readiness report (e.g. "database").
+	Name string
+	// Hard dependencies gate readiness; failures of soft dependencies are only reported.
+	Hard bool
+	// Probe returns an error when the dependency is unavailable. It must honor ctx.
+	Probe func(ctx context.Context) error
+}
+
+// DependencyStatus is the result of a single dependency check. Probe errors are only logged,
+// because they can name database hosts or API server addresses and the endpoint is reachable
+// through the gateway; Reason gives a generic cause instead.
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	Hard      bool   `json:"hard"`
+	LatencyMs int64  `json:"latencyMs"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ReadinessResponse is the body returned by GET /health/ready.
+type ReadinessResponse struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyStatus `json:"checks"`
+}
+
 // HealthHandler handles health check endpoints.
-type HealthHandler struct{}
+type HealthHandler struct {
+	checks       []DependencyCheck
+	checkTimeout time.Duration
+	logger       *logger.Logger
+}
 
-// NewHealthHandler creates a new health handler.
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler reporting on the given dependencies.
+func NewHealthHandler(log *logger.Logger, checks ...DependencyCheck) *HealthHandler {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &HealthHandler{
+		checks:       checks,
+		checkTimeout: defaultCheckTimeout,
+		logger:       log,
+	}
 }
 
 // HealthCheck handles GET /health.
+// It reports process liveness only and never probes dependencies.
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+	c.JSON(http.StatusOK, gin.H{"status": StatusHealthy})
+}
+
+// Readiness handles GET /health/ready.
+// All dependencies are probed concurrently, each bounded by its own timeout.
+// The response is 503 when any hard dependency fails.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	response := ReadinessResponse{
+		Status: StatusReady,
+		Checks: make(map[string]DependencyStatus, len(h.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range h.checks {
+		wg.Go(func() {
+			result := h.run(c.Request.Context(), check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			response.Checks[check.Name] = result
+			if check.Hard && result.Status != StatusHealthy {
+				response.Status = StatusNotReady
+			}
+		})
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	if response.Status != StatusReady {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
+}
+
+func (h *HealthHandler) run(ctx context.Context, check DependencyCheck) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Probe(ctx)
+
+	result := DependencyStatus{
+		Status:    StatusHealthy,
+		Hard:      check.Hard,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Reason = ReasonUnavailable
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Reason = ReasonTimeout
+		}
+		h.logger.Warn("Dependency check failed",
+			"dependency", check.Name,
+			"hard", check.Hard,
+			"error", err,
+		)
+	}
+	return result
 }
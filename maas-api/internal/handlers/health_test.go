@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
+)
+
+func TestReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	healthy := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name           string
+		checks         []handlers.DependencyCheck
+		expectedCode   int
+		expectedStatus string
+	}{
+		{
+			name: "all dependencies healthy",
+			checks: []handlers.DependencyCheck{
+				{Name: "database", Hard: true, Probe: healthy},
+				{Name: "kubernetes", Probe: healthy},
+			},
+			expectedCode:   http.StatusOK,
+			expectedStatus: handlers.StatusReady,
+		},
+		{
+			name: "soft dependency failure stays ready",
+			checks: []handlers.DependencyCheck{
+				{Name: "database", Hard: true, Probe: healthy},
+				{Name: "kubernetes", Probe: failing},
+			},
+			expectedCode:   http.StatusOK,
+			expectedStatus: handlers.StatusReady,
+		},
+		{
+			name: "hard dependency failure is not ready",
+			checks: []handlers.DependencyCheck{
+				{Name: "database", Hard: true, Probe: failing},
+				{Name: "kubernetes", Probe: healthy},
+			},
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: handlers.StatusNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/health/ready", handlers.NewHealthHandler(nil, tt.checks...).Readiness)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/health/ready", nil)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedCode, w.Code)
+
+			var response handlers.ReadinessResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			require.Len(t, response.Checks, len(tt.checks))
+
+			for _, check := range tt.checks {
+				result := response.Checks[check.Name]
+				assert.Equal(t, check.Hard, result.Hard)
+				if check.Probe(t.Context()) != nil {
+					assert.Equal(t, handlers.StatusUnhealthy, result.Status)
+					assert.Equal(t, handlers.ReasonUnavailable, result.Reason)
+					assert.NotContains(t, w.Body.String(), "connection refused", "probe errors must not be exposed")
+				} else {
+					assert.Equal(t, handlers.StatusHealthy, result.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthCheckDoesNotProbe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	probed := false
+	router := gin.New()
+	router.GET("/health", handlers.NewHealthHandler(nil, handlers.DependencyCheck{
+		Name: "database",
+		Hard: true,
+		Probe: func(context.Context) error {
+			probed = true
+			return errors.New("down")
+		},
+	}).HealthCheck)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/health", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, probed, "liveness must not depend on dependencies")
+}
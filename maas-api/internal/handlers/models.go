@@ -6,6 +6,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/openai/openai-go/v2/packages/pagination"
 
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/fields"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
 )
@@ -34,14 +36,11 @@ func (h *ModelsHandler) ListModels(c *gin.Context) {
 		h.logger.Error("Failed to get available models",
 			"error", err,
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve models"})
+		c.JSON(errcode.ModelsListFailed.Failure(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, pagination.Page[models.Model]{
-		Object: "list",
-		Data:   modelList,
-	})
+	h.respondWithModels(c, modelList)
 }
 
 // ListLLMs handles GET /v1/models.
@@ -55,12 +54,37 @@ func (h *ModelsHandler) ListLLMs(c *gin.Context) {
 			"error": gin.H{
 				"message": "Failed to retrieve LLM models",
 				"type":    "server_error",
+				"code":    string(errcode.ModelsListFailed),
 			}})
 		return
 	}
 
-	c.JSON(http.StatusOK, pagination.Page[models.Model]{
-		Object: "list",
-		Data:   modelList,
+	h.respondWithModels(c, modelList)
+}
+
+// respondWithModels writes the model list page. When ?fields= is set, each model
+// in data is reduced to the selected fields while the page envelope is kept.
+func (h *ModelsHandler) respondWithModels(c *gin.Context, modelList []models.Model) {
+	selected := fields.FromQuery(c)
+	if len(selected) == 0 {
+		c.JSON(http.StatusOK, pagination.Page[models.Model]{
+			Object: "list",
+			Data:   modelList,
+		})
+		return
+	}
+
+	projected, err := fields.Project(modelList, selected)
+	if err != nil {
+		h.logger.Error("Failed to project model fields",
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, errcode.ResponseFailed.Response())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"object": "list",
+		"data":   projected,
 	})
 }
@@ -205,6 +205,29 @@ func TestListingModels(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("field selection", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/models?fields=id,ready", nil)
+		require.NoError(t, err, "Failed to create request")
+
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, "Expected status OK")
+
+		var projected struct {
+			Object string           `json:"object"`
+			Data   []map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &projected), "Failed to unmarshal response body")
+
+		assert.Equal(t, "list", projected.Object)
+		require.Len(t, projected.Data, len(llmInferenceServices))
+		for _, model := range projected.Data {
+			assert.Len(t, model, 2, "Expected only selected fields, got %v", model)
+			assert.Contains(t, model, "id")
+			assert.Contains(t, model, "ready")
+		}
+	})
 }
 
 func mustParseURL(rawURL string) *apis.URL {
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusStarting = "starting"
+	StatusStarted  = "started"
+)
+
+// StartupPhase records a completed initialization phase.
+type StartupPhase struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// StartupResponse is the body returned by GET /health/startup.
+type StartupResponse struct {
+	Status    string         `json:"status"`
+	Phase     string         `json:"phase,omitempty"`
+	ElapsedMs int64          `json:"elapsedMs"`
+	Completed []StartupPhase `json:"completed"`
+}
+
+// StartupTracker reports initialization progress so that a startup probe can wait for
+// long phases (database migrations, informer cache sync) while liveness stays green.
+type StartupTracker struct {
+	mu           sync.RWMutex
+	started      time.Time
+	phase        string
+	phaseStarted time.Time
+	completed    []StartupPhase
+	done         bool
+}
+
+// NewStartupTracker creates a tracker; the elapsed time is measured from this call.
+func NewStartupTracker() *StartupTracker {
+	now := time.Now()
+	return &StartupTracker{
+		started:      now,
+		phaseStarted: now,
+		completed:    []StartupPhase{},
+	}
+}
+
+// Phase marks the beginning of a named initialization phase, completing the previous one.
+func (t *StartupTracker) Phase(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.completePhase()
+	t.phase = name
+	t.phaseStarted = time.Now()
+}
+
+// Done marks initialization as finished.
+func (t *StartupTracker) Done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.completePhase()
+	t.phase = ""
+	t.done = true
+}
+
+func (t *StartupTracker) completePhase() {
+	if t.phase == "" {
+		return
+	}
+	t.completed = append(t.completed, StartupPhase{
+		Name:       t.phase,
+		DurationMs: time.Since(t.phaseStarted).Milliseconds(),
+	})
+}
+
+// Startup handles GET /health/startup.
+// It returns 503 with the current phase while initializing and 200 once done.
+func (t *StartupTracker) Startup(c *gin.Context) {
+	t.mu.RLock()
+	response := StartupResponse{
+		Status:    StatusStarting,
+		Phase:     t.phase,
+		ElapsedMs: time.Since(t.started).Milliseconds(),
+		Completed: append([]StartupPhase(nil), t.completed...),
+	}
+	done := t.done
+	t.mu.RUnlock()
+
+	if !done {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	response.Status = StatusStarted
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
+)
+
+func TestStartup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tracker := handlers.NewStartupTracker()
+	router := gin.New()
+	router.GET("/health/startup", tracker.Startup)
+
+	probe := func(t *testing.T) (int, handlers.StartupResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/health/startup", nil)
+		router.ServeHTTP(w, req)
+
+		var response handlers.StartupResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	tracker.Phase("storage")
+	tracker.Phase("informer-sync")
+
+	code, response := probe(t)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, handlers.StatusStarting, response.Status)
+	assert.Equal(t, "informer-sync", response.Phase)
+	require.Len(t, response.Completed, 1)
+	assert.Equal(t, "storage", response.Completed[0].Name)
+
+	tracker.Done()
+
+	code, response = probe(t)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.StatusStarted, response.Status)
+	assert.Empty(t, response.Phase)
+	require.Len(t, response.Completed, 2)
+	assert.Equal(t, "informer-sync", response.Completed[1].Name)
+}
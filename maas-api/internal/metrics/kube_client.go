@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+)
+
+// throttleThreshold is the client-side rate limiter wait above which a request counts as throttled.
+// It matches the threshold at which client-go logs "Waited for ... due to client-side throttling".
+const throttleThreshold = 50 * time.Millisecond
+
+// KubeClient collects metrics for every request made through client-go REST clients
+// (the core clientset as well as the KServe and Gateway API clientsets).
+type KubeClient struct {
+	requestDuration     *prometheus.HistogramVec
+	requestResults      *prometheus.CounterVec
+	rateLimiterDuration *prometheus.HistogramVec
+	throttledRequests   *prometheus.CounterVec
+}
+
+// NewKubeClient creates the Kubernetes client metrics and registers them with reg.
+// Pass RegisterOpts to client-go's metrics.Register to start recording.
+func NewKubeClient(reg prometheus.Registerer) (*KubeClient, error) {
+	m := &KubeClient{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kube_client_request_duration_seconds",
+			Help:      "Latency of Kubernetes API requests, by verb and resource.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"verb", "resource"}),
+		requestResults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "kube_client_requests_total",
+			Help:      "Kubernetes API requests, by verb and HTTP status code.",
+		}, []string{"verb", "code"}),
+		rateLimiterDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kube_client_rate_limiter_duration_seconds",
+			Help:      "Time Kubernetes API requests spent waiting on the client-side rate limiter, by verb and resource.",
+			Buckets:   []float64{0.005, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		}, []string{"verb", "resource"}),
+		throttledRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "kube_client_throttled_requests_total",
+			Help:      "Kubernetes API requests delayed by the client-side rate limiter for more than 50ms, by verb and resource.",
+		}, []string{"verb", "resource"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.requestDuration, m.requestResults, m.rateLimiterDuration, m.throttledRequests} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// RegisterOpts returns the client-go hooks backed by these metrics.
+func (m *KubeClient) RegisterOpts() clientmetrics.RegisterOpts {
+	return clientmetrics.RegisterOpts{
+		RequestLatency:     latencyFunc(m.observeRequest),
+		RateLimiterLatency: latencyFunc(m.observeRateLimiter),
+		RequestResult:      resultFunc(m.countResult),
+	}
+}
+
+func (m *KubeClient) observeRequest(verb string, u url.URL, latency time.Duration) {
+	m.requestDuration.WithLabelValues(verb, resourceFromPath(u.Path)).Observe(latency.Seconds())
+}
+
+func (m *KubeClient) observeRateLimiter(verb string, u url.URL, latency time.Duration) {
+	resource := resourceFromPath(u.Path)
+	m.rateLimiterDuration.WithLabelValues(verb, resource).Observe(latency.Seconds())
+	if latency > throttleThreshold {
+		m.throttledRequests.WithLabelValues(verb, resource).Inc()
+	}
+}
+
+func (m *KubeClient) countResult(code, verb string) {
+	m.requestResults.WithLabelValues(verb, code).Inc()
+}
+
+type latencyFunc func(verb string, u url.URL, latency time.Duration)
+
+func (f latencyFunc) Observe(_ context.Context, verb string, u url.URL, latency time.Duration) {
+	f(verb, u, latency)
+}
+
+type resultFunc func(code, verb string)
+
+func (f resultFunc) Increment(_ context.Context, code, method, _ string) {
+	f(code, method)
+}
+
+// resourceFromPath derives a low-cardinality resource label from a client-go URL template,
+// in which names and namespaces are already replaced by placeholders such as {name}.
+// For example "/api/v1/namespaces/{namespace}/serviceaccounts/{name}/token" yields "serviceaccounts/token".
+func resourceFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(segments) > 2 && segments[0] == "api":
+		segments = segments[2:]
+	case len(segments) > 3 && segments[0] == "apis":
+		segments = segments[3:]
+	default:
+		return path
+	}
+
+	if len(segments) > 2 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+
+	parts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		if !strings.HasPrefix(s, "{") {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,76 @@
+package metrics_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+)
+
+func TestKubeClient(t *testing.T) {
+	ctx := t.Context()
+
+	reg := prometheus.NewRegistry()
+	m, err := metrics.NewKubeClient(reg)
+	require.NoError(t, err)
+	opts := m.RegisterOpts()
+
+	tokenURL := url.URL{Path: "/api/v1/namespaces/{namespace}/serviceaccounts/{name}/token"}
+	opts.RequestLatency.Observe(ctx, "POST", tokenURL, 20*time.Millisecond)
+	opts.RateLimiterLatency.Observe(ctx, "POST", tokenURL, time.Millisecond)
+	opts.RateLimiterLatency.Observe(ctx, "POST", tokenURL, 300*time.Millisecond)
+	opts.RequestResult.Increment(ctx, "201", "POST", "10.0.0.1:6443")
+
+	expected := `
+# HELP maas_api_kube_client_requests_total Kubernetes API requests, by verb and HTTP status code.
+# TYPE maas_api_kube_client_requests_total counter
+maas_api_kube_client_requests_total{code="201",verb="POST"} 1
+# HELP maas_api_kube_client_throttled_requests_total Kubernetes API requests delayed by the client-side rate limiter for more than 50ms, by verb and resource.
+# TYPE maas_api_kube_client_throttled_requests_total counter
+maas_api_kube_client_throttled_requests_total{resource="serviceaccounts/token",verb="POST"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"maas_api_kube_client_requests_total", "maas_api_kube_client_throttled_requests_total"))
+
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "maas_api_kube_client_request_duration_seconds"))
+
+	t.Run("ResourceLabels", func(t *testing.T) {
+		paths := map[string]string{
+			"/api/v1/namespaces/{name}":                                            "namespaces",
+			"/api/v1/namespaces/{namespace}/serviceaccounts":                       "serviceaccounts",
+			"/apis/serving.kserve.io/v1beta1/inferenceservices":                    "inferenceservices",
+			"/apis/gateway.networking.k8s.io/v1/namespaces/{namespace}/httproutes": "httproutes",
+			"/version": "/version",
+		}
+		for path := range paths {
+			opts.RequestLatency.Observe(ctx, "GET", url.URL{Path: path}, time.Millisecond)
+		}
+
+		families, err := reg.Gather()
+		require.NoError(t, err)
+
+		got := map[string]bool{}
+		for _, mf := range families {
+			if mf.GetName() != "maas_api_kube_client_request_duration_seconds" {
+				continue
+			}
+			for _, metric := range mf.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "resource" {
+						got[label.GetValue()] = true
+					}
+				}
+			}
+		}
+		for path, resource := range paths {
+			assert.True(t, got[resource], "expected resource %q for path %q", resource, path)
+		}
+	})
+}
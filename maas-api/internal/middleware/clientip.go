@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIP returns the canonical client IP for the request.
+// It relies on gin's ClientIP, which only honors X-Forwarded-For / X-Real-IP when the
+// direct peer is one of the engine's trusted proxies (see gin.Engine.SetTrustedProxies).
+// IPv4-mapped IPv6 addresses are unmapped and zones are dropped, so the same client is
+// always reported the same way. Unparseable values are returned as-is.
+func ClientIP(c *gin.Context) string {
+	raw := c.ClientIP()
+
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return raw
+	}
+	return addr.Unmap().WithZone("").String()
+}
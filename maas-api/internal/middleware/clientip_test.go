@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+)
+
+func TestClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		expected       string
+	}{
+		{
+			name:         "no trusted proxies ignores forwarded header",
+			remoteAddr:   "10.0.0.5:4321",
+			forwardedFor: "203.0.113.7",
+			expected:     "10.0.0.5",
+		},
+		{
+			name:           "trusted proxy forwards client address",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.5:4321",
+			forwardedFor:   "203.0.113.7",
+			expected:       "203.0.113.7",
+		},
+		{
+			name:           "untrusted peer cannot spoof forwarded header",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.168.1.9:4321",
+			forwardedFor:   "203.0.113.7",
+			expected:       "192.168.1.9",
+		},
+		{
+			name:       "ipv4-mapped ipv6 is unmapped",
+			remoteAddr: "[::ffff:10.0.0.5]:4321",
+			expected:   "10.0.0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			require.NoError(t, router.SetTrustedProxies(tt.trustedProxies))
+
+			var got string
+			router.GET("/test", func(c *gin.Context) {
+				got = middleware.ClientIP(c)
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
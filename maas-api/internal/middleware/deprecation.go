@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecatedRoute describes the retirement schedule of a single route.
+type DeprecatedRoute struct {
+	// Route is the HTTP method and the registered route path, e.g. "POST /v1/tiers/lookup".
+	Route string `json:"route"`
+	// Deprecated is when the route was (or will be) deprecated. Required.
+	Deprecated time.Time `json:"deprecated"`
+	// Sunset is when the route is expected to stop responding. Optional.
+	Sunset *time.Time `json:"sunset,omitempty"`
+	// Link points to migration documentation. Optional.
+	Link string `json:"link,omitempty"`
+}
+
+// ParseDeprecatedRoutes parses a JSON array of DeprecatedRoute entries, as passed via
+// the DEPRECATED_ROUTES environment variable. An empty string yields no entries.
+func ParseDeprecatedRoutes(raw string) ([]DeprecatedRoute, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var routes []DeprecatedRoute
+	if err := json.Unmarshal([]byte(raw), &routes); err != nil {
+		return nil, fmt.Errorf("failed to parse deprecated routes: %w", err)
+	}
+
+	for i, r := range routes {
+		method, path, ok := strings.Cut(strings.TrimSpace(r.Route), " ")
+		if !ok || method == "" || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("deprecated route %d: route must be \"METHOD /path\", got %q", i, r.Route)
+		}
+		if r.Deprecated.IsZero() {
+			return nil, fmt.Errorf("deprecated route %q: deprecated date is required", r.Route)
+		}
+		if r.Sunset != nil && r.Sunset.Before(r.Deprecated) {
+			return nil, fmt.Errorf("deprecated route %q: sunset must not be before deprecation", r.Route)
+		}
+		routes[i].Route = strings.ToUpper(method) + " " + path
+	}
+
+	return routes, nil
+}
+
+// Deprecation emits machine-readable retirement notices on matching routes:
+// the Deprecation header (RFC 9745), the Sunset header (RFC 8594) and a
+// Link header with rel="deprecation" / rel="sunset" when a link is configured.
+// Routes are matched on the method and the registered route path (c.FullPath()).
+func Deprecation(routes []DeprecatedRoute) gin.HandlerFunc {
+	byRoute := make(map[string]DeprecatedRoute, len(routes))
+	for _, r := range routes {
+		byRoute[r.Route] = r
+	}
+
+	return func(c *gin.Context) {
+		r, ok := byRoute[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		c.Header("Deprecation", "@"+strconv.FormatInt(r.Deprecated.Unix(), 10))
+		if r.Sunset != nil {
+			c.Header("Sunset", r.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if r.Link != "" {
+			rel := "deprecation"
+			if r.Sunset != nil {
+				rel = "deprecation sunset"
+			}
+			c.Header("Link", fmt.Sprintf("<%s>; rel=%q", r.Link, rel))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,92 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+)
+
+func TestParseDeprecatedRoutes(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		routes, err := middleware.ParseDeprecatedRoutes("  ")
+		require.NoError(t, err)
+		assert.Empty(t, routes)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		routes, err := middleware.ParseDeprecatedRoutes(`[
+			{"route": "post /v1/tiers/lookup", "deprecated": "2026-01-01T00:00:00Z", "sunset": "2026-07-01T00:00:00Z", "link": "https://example.com/migrate"}
+		]`)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Equal(t, "POST /v1/tiers/lookup", routes[0].Route)
+		require.NotNil(t, routes[0].Sunset)
+	})
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "InvalidJSON", raw: `{not json`},
+		{name: "MissingMethod", raw: `[{"route": "/v1/tokens", "deprecated": "2026-01-01T00:00:00Z"}]`},
+		{name: "MissingDeprecatedDate", raw: `[{"route": "POST /v1/tokens"}]`},
+		{name: "SunsetBeforeDeprecation", raw: `[{"route": "POST /v1/tokens", "deprecated": "2026-01-01T00:00:00Z", "sunset": "2025-01-01T00:00:00Z"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := middleware.ParseDeprecatedRoutes(tt.raw)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDeprecation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	routes, err := middleware.ParseDeprecatedRoutes(`[
+		{"route": "GET /v1/legacy/:id", "deprecated": "2026-01-01T00:00:00Z", "sunset": "2026-07-01T00:00:00Z", "link": "https://example.com/migrate"},
+		{"route": "POST /v1/legacy", "deprecated": "2026-01-01T00:00:00Z"}
+	]`)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware.Deprecation(routes))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/v1/legacy/:id", ok)
+	router.POST("/v1/legacy", ok)
+	router.GET("/v1/current", ok)
+
+	t.Run("FullNotice", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/legacy/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", w.Header().Get("Sunset"))
+		assert.Equal(t, `<https://example.com/migrate>; rel="deprecation sunset"`, w.Header().Get("Link"))
+	})
+
+	t.Run("DeprecationOnly", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/legacy", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Sunset"))
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+
+	t.Run("NotDeprecated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/current", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Empty(t, w.Header().Get("Deprecation"))
+	})
+}
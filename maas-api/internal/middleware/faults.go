@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/faults"
+)
+
+// InjectFaults attaches faults to the request context for dependencies wrapped with fault
+// injection. The X-MaaS-Fault header replaces defaults for a single request. Development only.
+func InjectFaults(defaults faults.Faults) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f := defaults
+		if spec := c.GetHeader(faults.Header); spec != "" {
+			parsed, err := faults.Parse(spec)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail(err.Error()))
+				return
+			}
+			f = parsed
+		}
+		c.Request = c.Request.WithContext(faults.NewContext(c.Request.Context(), f))
+		c.Next()
+	}
+}
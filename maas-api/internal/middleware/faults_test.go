@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/faults"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+)
+
+func TestInjectFaults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var got faults.Faults
+	router := gin.New()
+	router.Use(middleware.InjectFaults(faults.Faults{DBError: true}))
+	router.GET("/", func(c *gin.Context) {
+		got = faults.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(header string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(faults.Header, header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(""))
+		assert.Equal(t, faults.Faults{DBError: true}, got)
+	})
+
+	t.Run("HeaderReplacesDefaults", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("db-latency=1ms"))
+		assert.Equal(t, faults.Faults{DBLatency: time.Millisecond}, got)
+	})
+
+	t.Run("InvalidHeader", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("unknown"))
+	})
+}
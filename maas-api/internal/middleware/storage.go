@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+)
+
+// storageRetryAfter is the Retry-After hint, in seconds, while storage is unavailable.
+const storageRetryAfter = "5"
+
+// RequireStorage rejects requests with 503 while the metadata store is not connected,
+// so routes that depend on the database fail fast when the server runs in degraded mode.
+func RequireStorage(connected func() bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !connected() {
+			c.Header("Retry-After", storageRetryAfter)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, errcode.StorageUnavailable.Response())
+			return
+		}
+		c.Next()
+	}
+}
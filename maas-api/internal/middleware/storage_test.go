@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+)
+
+func TestRequireStorage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var connected atomic.Bool
+	router := gin.New()
+	router.GET("/test", middleware.RequireStorage(connected.Load), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve()
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "STORAGE_UNAVAILABLE")
+
+	connected.Store(true)
+	assert.Equal(t, http.StatusOK, serve().Code)
+}
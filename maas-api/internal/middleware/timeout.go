@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+)
+
+// Timeout bounds the request context with the given deadline, so that downstream
+// calls made with c.Request.Context() (database, Kubernetes API) are cancelled once
+// the route budget is spent instead of running until the server WriteTimeout.
+// If the deadline expires before the handler writes a response, 504 is returned.
+// Handlers report failed calls cancelled by the deadline as 504 through errcode.Code.Failure.
+// A non-positive timeout disables the middleware.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, errcode.RequestTimeout.Response())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("DeadlinePropagatedToHandler", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", middleware.Timeout(time.Minute), func(c *gin.Context) {
+			deadline, ok := c.Request.Context().Deadline()
+			require.True(t, ok, "expected request context to carry a deadline")
+			assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("GatewayTimeoutWhenNothingWritten", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", middleware.Timeout(10*time.Millisecond), func(c *gin.Context) {
+			<-c.Request.Context().Done()
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), "Request timed out")
+	})
+
+	t.Run("HandlerReportsDeadlineAsGatewayTimeout", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", middleware.Timeout(10*time.Millisecond), func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			err := fmt.Errorf("failed to list api keys: %w", c.Request.Context().Err())
+			c.JSON(errcode.APIKeyListFailed.Failure(err))
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, string(errcode.RequestTimeout), body["code"])
+	})
+
+	t.Run("DisabledWhenNonPositive", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", middleware.Timeout(0), func(c *gin.Context) {
+			_, ok := c.Request.Context().Deadline()
+			assert.False(t, ok, "expected no deadline when timeout is disabled")
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,157 @@
+// Package ratelimit protects token issuance from misbehaving clients.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+// sweepInterval is how often idle per-user state is discarded.
+const sweepInterval = time.Minute
+
+// Issuance limits how often each user may obtain tokens and API keys, and blocks issuance
+// for a cooldown after the user revokes all tokens, so that a client stuck in a
+// revoke/issue loop cannot churn service accounts on the API server.
+// State is kept in memory per replica.
+type Issuance struct {
+	limit    rate.Limit
+	burst    int
+	cooldown time.Duration
+	idleTTL  time.Duration
+
+	mu        sync.Mutex
+	users     map[string]*userState
+	lastSweep time.Time
+}
+
+type userState struct {
+	limiter       *rate.Limiter
+	cooldownUntil time.Time
+	lastSeen      time.Time
+}
+
+// NewIssuance creates an issuance limiter allowing perMinute requests per user with the given burst.
+// A non-positive perMinute disables rate limiting; a non-positive cooldown disables the revoke cooldown.
+func NewIssuance(perMinute, burst int, cooldown time.Duration) *Issuance {
+	l := &Issuance{
+		limit:    rate.Inf,
+		burst:    max(burst, 1),
+		cooldown: max(cooldown, 0),
+		users:    make(map[string]*userState),
+	}
+	if perMinute > 0 {
+		interval := time.Minute / time.Duration(perMinute)
+		l.limit = rate.Every(interval)
+		// A limiter left idle this long has refilled completely and is equivalent to a new one.
+		l.idleTTL = time.Duration(l.burst) * interval
+	}
+	l.idleTTL = max(l.idleTTL, l.cooldown)
+	return l
+}
+
+// Limit rejects issuance requests with 429 and a Retry-After header while the caller
+// is over its rate or in a post-revocation cooldown. It must run after ExtractUserInfo.
+func (l *Issuance) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := userFrom(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		code, retryAfter := l.allow(user.Username)
+		if code != "" {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, code.Response())
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// CooldownOnRevoke starts the caller's issuance cooldown once a revocation succeeds.
+// It must run after ExtractUserInfo.
+func (l *Issuance) CooldownOnRevoke() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if l.cooldown <= 0 || c.Writer.Status() != http.StatusNoContent {
+			return
+		}
+		if user, ok := userFrom(c); ok {
+			l.startCooldown(user.Username)
+		}
+	}
+}
+
+func (l *Issuance) allow(username string) (errcode.Code, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+	state := l.state(username, now)
+
+	if now.Before(state.cooldownUntil) {
+		return errcode.TokenRevokeCooldown, state.cooldownUntil.Sub(now)
+	}
+
+	reservation := state.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return errcode.TokenRateLimited, delay
+	}
+
+	return "", 0
+}
+
+func (l *Issuance) startCooldown(username string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.state(username, now).cooldownUntil = now.Add(l.cooldown)
+}
+
+func (l *Issuance) state(username string, now time.Time) *userState {
+	state, ok := l.users[username]
+	if !ok {
+		state = &userState{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.users[username] = state
+	}
+	state.lastSeen = now
+	return state
+}
+
+// sweep discards state that is indistinguishable from a fresh user. Callers must hold l.mu.
+func (l *Issuance) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for username, state := range l.users {
+		if now.Sub(state.lastSeen) > l.idleTTL && !now.Before(state.cooldownUntil) {
+			delete(l.users, username)
+		}
+	}
+}
+
+func userFrom(c *gin.Context) (*token.UserContext, bool) {
+	userCtx, exists := c.Get("user")
+	if !exists {
+		return nil, false
+	}
+	user, ok := userCtx.(*token.UserContext)
+	return user, ok
+}
@@ -0,0 +1,89 @@
+package ratelimit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/ratelimit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+func newRouter(limiter *ratelimit.Issuance, revokeStatus int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &token.UserContext{Username: c.GetHeader("X-User")})
+	})
+	router.POST("/v1/tokens", limiter.Limit(), func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.DELETE("/v1/tokens", limiter.CooldownOnRevoke(), func(c *gin.Context) { c.Status(revokeStatus) })
+	return router
+}
+
+func do(t *testing.T, router *gin.Engine, method, user string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), method, "/v1/tokens", nil)
+	req.Header.Set("X-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	code, _ := body["code"].(string)
+	return code
+}
+
+func TestIssuanceRateLimit(t *testing.T) {
+	router := newRouter(ratelimit.NewIssuance(1, 2, 0), http.StatusNoContent)
+
+	assert.Equal(t, http.StatusCreated, do(t, router, http.MethodPost, "alice").Code)
+	assert.Equal(t, http.StatusCreated, do(t, router, http.MethodPost, "alice").Code)
+
+	w := do(t, router, http.MethodPost, "alice")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "TOKEN_RATE_LIMITED", errorCode(t, w))
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusCreated, do(t, router, http.MethodPost, "bob").Code, "limits are per user")
+}
+
+func TestIssuanceRateLimitDisabled(t *testing.T) {
+	router := newRouter(ratelimit.NewIssuance(0, 1, 0), http.StatusNoContent)
+
+	for range 20 {
+		require.Equal(t, http.StatusCreated, do(t, router, http.MethodPost, "alice").Code)
+	}
+}
+
+func TestRevokeCooldown(t *testing.T) {
+	t.Run("successful revocation starts the cooldown", func(t *testing.T) {
+		router := newRouter(ratelimit.NewIssuance(0, 1, time.Minute), http.StatusNoContent)
+
+		require.Equal(t, http.StatusNoContent, do(t, router, http.MethodDelete, "alice").Code)
+
+		w := do(t, router, http.MethodPost, "alice")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "TOKEN_REVOKE_COOLDOWN", errorCode(t, w))
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusCreated, do(t, router, http.MethodPost, "bob").Code)
+	})
+
+	t.Run("failed revocation does not", func(t *testing.T) {
+		router := newRouter(ratelimit.NewIssuance(0, 1, time.Minute), http.StatusInternalServerError)
+
+		require.Equal(t, http.StatusInternalServerError, do(t, router, http.MethodDelete, "alice").Code)
+		assert.Equal(t, http.StatusCreated, do(t, router, http.MethodPost, "alice").Code)
+	})
+}
@@ -5,15 +5,26 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 )
 
 type Handler struct {
-	mapper *Mapper
+	mapper   *Mapper
+	notifier *Notifier
+	logger   *logger.Logger
 }
 
-func NewHandler(mapper *Mapper) *Handler {
+// NewHandler creates a tier handler. The notifier supplies change history and may be nil.
+func NewHandler(log *logger.Logger, mapper *Mapper, notifier *Notifier) *Handler {
+	if log == nil {
+		log = logger.Production()
+	}
 	return &Handler{
-		mapper: mapper,
+		mapper:   mapper,
+		notifier: notifier,
+		logger:   log,
 	}
 }
 
@@ -29,6 +40,7 @@ func (h *Handler) TierLookup(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "bad_request",
 			Message: "invalid request body: " + err.Error(),
+			Code:    string(errcode.InvalidRequest),
 		})
 		return
 	}
@@ -40,14 +52,20 @@ func (h *Handler) TierLookup(c *gin.Context) {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
 				Message: err.Error(),
+				Code:    string(errcode.TierNotFound),
 			})
 			return
 		}
 
 		// All other errors are internal server errors
+		h.logger.Error("Failed to look up tier",
+			"groups", req.Groups,
+			"error", err,
+		)
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
-			Message: "failed to lookup tier: " + err.Error(),
+			Message: errcode.TierLookupFailed.Message(),
+			Code:    string(errcode.TierLookupFailed),
 		})
 		return
 	}
@@ -64,3 +82,73 @@ func (h *Handler) TierLookup(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// GetTier handles GET /tiers/:name, returning the effective tier configuration,
+// its namespace and its recent configuration changes.
+func (h *Handler) GetTier(c *gin.Context) {
+	t, err := h.mapper.Tier(c.Param("name"))
+	if err != nil {
+		if errors.Is(err, ErrTierNotFound) {
+			c.JSON(http.StatusNotFound, errcode.TierNotFound.ResponseWithDetail(err.Error()))
+			return
+		}
+
+		h.logger.Error("Failed to load tier",
+			"tier", c.Param("name"),
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, errcode.TierLookupFailed.Response())
+		return
+	}
+
+	displayName := t.DisplayName
+	if displayName == "" {
+		displayName = t.Name
+	}
+
+	response := TierResponse{
+		Name:        t.Name,
+		DisplayName: displayName,
+		Description: t.Description,
+		Level:       t.Level,
+		Groups:      t.Groups,
+		Namespace:   h.mapper.ProjectedNsName(t),
+		Audiences:   t.Token.Audiences,
+		History:     []Change{},
+	}
+	if t.Token.MaxExpiration > 0 {
+		response.MaxExpiration = t.Token.MaxExpiration.String()
+	}
+	if h.notifier != nil {
+		response.History = h.notifier.History(t.Name)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RateLimitSpec handles POST /tools/ratelimit-spec, converting a rate limit description into the
+// spec.limits entry of the Kuadrant policy that enforces it, as JSON and as YAML.
+func (h *Handler) RateLimitSpec(c *gin.Context) {
+	var req RateLimitSpecRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail("invalid request body: "+err.Error()))
+		return
+	}
+
+	spec, err := BuildRateLimitSpec(req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRateLimit) {
+			c.JSON(http.StatusBadRequest, errcode.InvalidRateLimit.ResponseWithDetail(err.Error()))
+			return
+		}
+
+		h.logger.Error("Failed to build rate limit spec",
+			"tier", req.Tier,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, errcode.RateLimitSpecFailed.Response())
+		return
+	}
+
+	c.JSON(http.StatusOK, spec)
+}
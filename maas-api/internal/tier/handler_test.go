@@ -11,11 +11,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tier"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/test/fixtures"
 )
 
+// errorResponse is the standard error body built by errcode.
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
 // createTestMapper wraps the unified fixtures function for backward compatibility.
 func createTestMapper(withConfigMap bool) *tier.Mapper {
 	return fixtures.CreateTestMapper(withConfigMap)
@@ -136,6 +143,12 @@ func TestHandler_PostTierLookup_GroupNotFound(t *testing.T) {
 	if response.Error != "not_found" {
 		t.Errorf("expected error 'not_found', got '%s'", response.Error)
 	}
+	if response.Message == "" {
+		t.Error("expected a message")
+	}
+	if response.Code != string(errcode.TierNotFound) {
+		t.Errorf("expected code %s, got '%s'", errcode.TierNotFound, response.Code)
+	}
 }
 
 func TestHandler_PostTierLookup_BadRequest(t *testing.T) {
@@ -188,6 +201,9 @@ func TestHandler_PostTierLookup_BadRequest(t *testing.T) {
 			if response.Error != "bad_request" {
 				t.Errorf("expected error 'bad_request', got '%s'", response.Error)
 			}
+			if response.Code != string(errcode.InvalidRequest) {
+				t.Errorf("expected code %s, got '%s'", errcode.InvalidRequest, response.Code)
+			}
 		})
 	}
 }
@@ -257,3 +273,90 @@ func TestHandler_PostTierLookup_DisplayNameFallback(t *testing.T) {
 		t.Errorf("expected displayName to fall back to 'basic', got %s", response.DisplayName)
 	}
 }
+
+func TestHandler_GetTier(t *testing.T) {
+	mapper := createTestMapper(true)
+	router := fixtures.SetupTierTestRouter(mapper)
+
+	get := func(name string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/tiers/"+name, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("enterprise")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response tier.TierResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Namespace != fixtures.TestTenant+"-tier-enterprise" {
+		t.Errorf("unexpected namespace %s", response.Namespace)
+	}
+	if response.Level != 20 || response.MaxExpiration != "720h0m0s" {
+		t.Errorf("unexpected tier settings: level %d, maxExpiration %s", response.Level, response.MaxExpiration)
+	}
+	if response.History == nil {
+		t.Error("expected empty history array, got null")
+	}
+
+	w = get("unknown")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for unknown tier, got %d", http.StatusNotFound, w.Code)
+	}
+	var errResponse errorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &errResponse); err != nil {
+		t.Fatalf("failed to unmarshal error response: %v", err)
+	}
+	if errResponse.Code != string(errcode.TierNotFound) {
+		t.Errorf("expected code %s, got '%s'", errcode.TierNotFound, errResponse.Code)
+	}
+}
+
+func TestHandler_RateLimitSpec(t *testing.T) {
+	router := fixtures.SetupTierTestRouter(createTestMapper(true))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "/tools/ratelimit-spec", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := post(`{"tier": "premium", "limit": 20, "window": "2m"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		expectedCode errcode.Code
+	}{
+		{"invalid JSON", "{invalid json}", errcode.InvalidRequest},
+		{"invalid window", `{"tier": "premium", "limit": 20, "window": "2 minutes"}`, errcode.InvalidRateLimit},
+		{"burst above limit", `{"tier": "premium", "limit": 20, "window": "2m", "burst": {"limit": 30, "window": "10s"}}`, errcode.InvalidRateLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.requestBody)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			var response errorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal error response: %v", err)
+			}
+			if response.Code != string(tt.expectedCode) {
+				t.Errorf("expected code %s, got '%s'", tt.expectedCode, response.Code)
+			}
+		})
+	}
+}
@@ -9,7 +9,22 @@ type LookupResponse struct {
 	DisplayName string `json:"displayName"`
 }
 
+type TierResponse struct {
+	Name          string   `json:"name"`
+	DisplayName   string   `json:"displayName"`
+	Description   string   `json:"description,omitempty"`
+	Level         int      `json:"level"`
+	Groups        []string `json:"groups"`                  // Includes the tier's projected service account group
+	Namespace     string   `json:"namespace"`               // Namespace holding the tier's service accounts
+	Audiences     []string `json:"audiences,omitempty"`     // Extra token audiences for tier members
+	MaxExpiration string   `json:"maxExpiration,omitempty"` // Longest token lifetime, if limited
+	History       []Change `json:"history"`                 // Recent changes recorded on the tier namespace, newest first
+}
+
+// ErrorResponse is the error body of POST /tiers/lookup. The gateway AuthPolicy depends on
+// Error holding the legacy code, so Code carries the errcode value alongside it.
 type ErrorResponse struct {
-	Error   string `json:"error"`   // Error code (e.g., "bad_request", "not_found")
+	Error   string `json:"error"`   // Legacy error code (e.g., "bad_request", "not_found")
 	Message string `json:"message"` // Human-readable error message
+	Code    string `json:"code"`    // Error code from the errcode catalog
 }
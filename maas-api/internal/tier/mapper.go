@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
@@ -36,18 +37,27 @@ func NewMapper(log *logger.Logger, configMapLister corelisters.ConfigMapLister,
 }
 
 func (m *Mapper) Namespace(tier string) (string, error) {
-	tiers, err := m.loadTierConfig()
+	t, err := m.Tier(tier)
 	if err != nil {
 		return "", err
 	}
+	return m.ProjectedNsName(t), nil
+}
+
+// Tier returns the effective configuration of the named tier, including its projected SA group.
+func (m *Mapper) Tier(name string) (*Tier, error) {
+	tiers, err := m.loadTierConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	for i := range tiers {
-		if tiers[i].Name == tier {
-			return m.ProjectedNsName(&tiers[i]), nil
+		if tiers[i].Name == name {
+			return &tiers[i], nil
 		}
 	}
 
-	return "", fmt.Errorf("tier %s not found", tier)
+	return nil, fmt.Errorf("tier %s: %w", name, ErrTierNotFound)
 }
 
 // GetTierForGroups returns the highest level tier for a user with multiple group memberships.
@@ -100,6 +110,21 @@ func (m *Mapper) loadTierConfig() ([]Tier, error) {
 		return nil, err
 	}
 
+	tiers, err := m.parseTierConfig(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tiers {
+		tier := &tiers[i]
+		tier.Groups = append(tier.Groups, m.ProjectedSAGroup(tier))
+	}
+
+	return tiers, nil
+}
+
+// parseTierConfig reads the tiers as configured, without the projected SA groups.
+func (m *Mapper) parseTierConfig(cm *corev1.ConfigMap) ([]Tier, error) {
 	configData, exists := cm.Data["tiers"]
 	if !exists {
 		m.logger.Warn("Tiers key not found in ConfigMap",
@@ -118,17 +143,13 @@ func (m *Mapper) loadTierConfig() ([]Tier, error) {
 		return nil, fmt.Errorf("invalid tier configuration: %w", err)
 	}
 
-	for i := range tiers {
-		tier := &tiers[i]
-		tier.Groups = append(tier.Groups, m.ProjectedSAGroup(tier))
-	}
-
 	return tiers, nil
 }
 
 // validateTierConfig validates that tier configuration is valid:
 // - All tier names must be unique
-// - If displayName is provided, it must be non-empty.
+// - If displayName is provided, it must be non-empty
+// - Token audiences must be non-empty and maxExpiration must not be negative.
 func validateTierConfig(tiers []Tier) error {
 	seenNames := make(map[string]bool)
 
@@ -145,6 +166,13 @@ func validateTierConfig(tiers []Tier) error {
 		if tier.DisplayName != "" && strings.TrimSpace(tier.DisplayName) == "" {
 			return fmt.Errorf("tier %q has whitespace-only displayName", tier.Name)
 		}
+
+		if tier.Token.MaxExpiration < 0 {
+			return fmt.Errorf("tier %q has negative token maxExpiration", tier.Name)
+		}
+		if slices.ContainsFunc(tier.Token.Audiences, func(a string) bool { return strings.TrimSpace(a) == "" }) {
+			return fmt.Errorf("tier %q has an empty token audience", tier.Name)
+		}
 	}
 
 	return nil
@@ -228,6 +228,31 @@ func TestMapper_GetTierForGroups_InvalidConfig(t *testing.T) {
 `,
 			errContains: "empty name",
 		},
+		{
+			name: "negative token maxExpiration",
+			tiersYAML: `
+- name: free
+  level: 0
+  groups:
+  - group-a
+  token:
+    maxExpiration: -1h
+`,
+			errContains: "negative token maxExpiration",
+		},
+		{
+			name: "empty token audience",
+			tiersYAML: `
+- name: free
+  level: 0
+  groups:
+  - group-a
+  token:
+    audiences:
+    - ""
+`,
+			errContains: "empty token audience",
+		},
 	}
 
 	for _, tt := range tests {
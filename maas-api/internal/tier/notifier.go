@@ -0,0 +1,457 @@
+package tier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+)
+
+// Annotations kept on tier namespaces so workloads and the gateway auth configuration can
+// watch the namespace and react when its tier changes.
+const (
+	// AnnotationTierHash identifies the tier configuration currently in effect for the namespace.
+	AnnotationTierHash = "maas.opendatahub.io/tier-hash"
+	// AnnotationTierRevision is the resourceVersion of the tier mapping ConfigMap that last changed the tier.
+	AnnotationTierRevision = "maas.opendatahub.io/tier-revision"
+	// AnnotationTierHistory holds the tier's recent changes as a JSON array of Change, oldest first.
+	AnnotationTierHistory = "maas.opendatahub.io/tier-history"
+)
+
+const (
+	ChangeAdded   = "added"
+	ChangeUpdated = "updated"
+	ChangeRemoved = "removed"
+
+	// Labels set on the tier namespaces when they are created for the first issued token.
+	labelTierNamespace = "maas.opendatahub.io/tier-namespace"
+	labelTier          = "maas.opendatahub.io/tier"
+	labelInstance      = "maas.opendatahub.io/instance"
+
+	eventReasonTierChanged = "TierChanged"
+	historyLimit           = 20
+	notifyTimeout          = 10 * time.Second
+)
+
+// Change describes one change to a tier's configuration.
+type Change struct {
+	Type string `json:"type"`
+	// Fields lists the changed settings; empty when the change was detected at startup
+	// and the previous configuration is unknown.
+	Fields    []string        `json:"fields,omitempty"`
+	Revision  string          `json:"revision"`
+	ChangedAt types.Timestamp `json:"changedAt"`
+}
+
+// Notifier watches the tier mapping ConfigMap and publishes tier changes to the tier namespaces:
+// each affected namespace is annotated with the new configuration hash, appends the change to its
+// history annotation and receives a TierChanged event. Keeping the history on the namespace shares
+// it between replicas and across restarts; tiers without a namespace have no history.
+type Notifier struct {
+	mapper          *Mapper
+	clientset       kubernetes.Interface
+	namespaceLister corelisters.NamespaceLister
+	logger          *logger.Logger
+
+	// pending holds the ConfigMap versions still to be synced by Run. Versions that arrive while
+	// a sync is pending are collapsed into it, so the informer never waits on the Kubernetes API.
+	mu      sync.Mutex
+	pending *syncRequest
+	wake    chan struct{}
+}
+
+type syncRequest struct {
+	previous, current *corev1.ConfigMap
+}
+
+func NewNotifier(log *logger.Logger, mapper *Mapper, clientset kubernetes.Interface, namespaceLister corelisters.NamespaceLister) *Notifier {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Notifier{
+		mapper:          mapper,
+		clientset:       clientset,
+		namespaceLister: namespaceLister,
+		logger:          log,
+		wake:            make(chan struct{}, 1),
+	}
+}
+
+// Run syncs the ConfigMap versions queued by EventHandler until ctx is cancelled.
+func (n *Notifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-n.wake:
+			n.mu.Lock()
+			req := n.pending
+			n.pending = nil
+			n.mu.Unlock()
+
+			if req != nil {
+				n.Sync(ctx, req.previous, req.current)
+			}
+		}
+	}
+}
+
+// enqueue schedules a sync without blocking. A pending sync keeps its previous version and
+// takes the new current one, so intermediate versions are compared as one change.
+func (n *Notifier) enqueue(previous, current *corev1.ConfigMap) {
+	if previous != nil && previous.ResourceVersion == current.ResourceVersion {
+		return // periodic resync
+	}
+
+	n.mu.Lock()
+	if n.pending != nil {
+		n.pending.current = current
+	} else {
+		n.pending = &syncRequest{previous: previous, current: current}
+	}
+	n.mu.Unlock()
+
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// EventHandler returns the ConfigMap informer handler that drives notifications.
+// Handlers only queue the change; Run performs the sync.
+func (n *Notifier) EventHandler() cache.ResourceEventHandler {
+	return cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj any) bool {
+			cm, ok := obj.(*corev1.ConfigMap)
+			return ok && cm.Name == constant.TierMappingConfigMap && cm.Namespace == n.mapper.namespace
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj any) {
+				if cm, ok := obj.(*corev1.ConfigMap); ok {
+					n.enqueue(nil, cm)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj any) {
+				previous, okOld := oldObj.(*corev1.ConfigMap)
+				current, okNew := newObj.(*corev1.ConfigMap)
+				if okOld && okNew {
+					n.enqueue(previous, current)
+				}
+			},
+		},
+	}
+}
+
+// History returns the recorded changes of a tier, newest first, as annotated on its namespace.
+func (n *Notifier) History(tierName string) []Change {
+	namespace := n.mapper.ProjectedNsName(&Tier{Name: tierName})
+	ns, err := n.namespaceLister.Get(namespace)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			n.logger.Error("Failed to look up tier namespace",
+				"tier", tierName,
+				"error", err,
+			)
+		}
+		return []Change{}
+	}
+
+	changes := n.parseHistory(ns)
+	slices.Reverse(changes)
+	return changes
+}
+
+// parseHistory decodes the history annotation of a namespace, oldest first.
+func (n *Notifier) parseHistory(ns *corev1.Namespace) []Change {
+	changes := []Change{}
+	value, ok := ns.Annotations[AnnotationTierHistory]
+	if !ok {
+		return changes
+	}
+	if err := json.Unmarshal([]byte(value), &changes); err != nil {
+		n.logger.Warn("Ignoring invalid tier history annotation",
+			"namespace", ns.Name,
+			"error", err,
+		)
+		return []Change{}
+	}
+	return changes
+}
+
+// Sync publishes the differences between two versions of the tier mapping ConfigMap.
+// A nil previous version means the configuration was just loaded; tiers are then compared
+// with the hash annotated on their namespaces, and annotated namespaces whose tier is gone are
+// reported as removed, so changes made while no replica was running are still published.
+func (n *Notifier) Sync(ctx context.Context, previous, current *corev1.ConfigMap) {
+	if previous != nil && previous.ResourceVersion == current.ResourceVersion {
+		return // periodic resync
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	currentTiers, err := n.mapper.parseTierConfig(current)
+	if err != nil {
+		n.logger.Warn("Ignoring invalid tier configuration update",
+			"revision", current.ResourceVersion,
+			"error", err,
+		)
+		return
+	}
+
+	var previousTiers []Tier
+	if previous != nil {
+		// An invalid previous version was never in effect, so every tier counts as added.
+		previousTiers, _ = n.mapper.parseTierConfig(previous)
+	}
+
+	for _, name := range tierNames(previousTiers, currentTiers) {
+		prev := findTier(previousTiers, name)
+		cur := findTier(currentTiers, name)
+		n.syncTier(ctx, previous == nil, prev, cur, current.ResourceVersion)
+	}
+	if previous == nil {
+		n.syncRemovedTiers(ctx, currentTiers, current.ResourceVersion)
+	}
+}
+
+// syncRemovedTiers publishes the removal of tiers that were deleted while no replica was running:
+// their namespaces still carry a hash annotation but no longer have a tier in the configuration.
+func (n *Notifier) syncRemovedTiers(ctx context.Context, currentTiers []Tier, revision string) {
+	selector := labels.SelectorFromSet(labels.Set{labelTierNamespace: "true", labelInstance: n.mapper.tenantName})
+	namespaces, err := n.namespaceLister.List(selector)
+	if err != nil {
+		n.logger.Error("Failed to list tier namespaces",
+			"error", err,
+		)
+		return
+	}
+
+	for _, ns := range namespaces {
+		if _, ok := ns.Annotations[AnnotationTierHash]; !ok {
+			continue
+		}
+		name := ns.Labels[labelTier]
+		if name == "" || findTier(currentTiers, name) != nil || n.mapper.ProjectedNsName(&Tier{Name: name}) != ns.Name {
+			continue
+		}
+		n.syncTier(ctx, false, &Tier{Name: name}, nil, revision)
+	}
+}
+
+func (n *Notifier) syncTier(ctx context.Context, initial bool, prev, cur *Tier, revision string) {
+	name, hash := "", ""
+	if cur != nil {
+		name, hash = cur.Name, specHash(cur)
+	} else {
+		name = prev.Name
+	}
+	namespace := n.mapper.ProjectedNsName(&Tier{Name: name})
+
+	ns, err := n.namespaceLister.Get(namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		n.logger.Error("Failed to look up tier namespace",
+			"tier", name,
+			"error", err,
+		)
+		return
+	}
+
+	change := Change{Revision: revision, ChangedAt: types.NewTimestamp(time.Now())}
+	switch {
+	case initial:
+		// Only namespaces annotated with a different configuration have missed a change;
+		// unannotated namespaces predate notifications and are annotated silently.
+		if ns == nil {
+			return
+		}
+		annotated, ok := ns.Annotations[AnnotationTierHash]
+		if ok && annotated == hash {
+			return
+		}
+		if !ok {
+			n.publish(ctx, namespace, hash, revision, nil)
+			return
+		}
+		change.Type = ChangeUpdated
+	case prev == nil:
+		change.Type = ChangeAdded
+	case cur == nil:
+		change.Type = ChangeRemoved
+	default:
+		change.Type = ChangeUpdated
+		change.Fields = changedFields(prev, cur)
+		if len(change.Fields) == 0 {
+			return
+		}
+	}
+
+	if ns == nil {
+		return // nothing to notify or record until the first token is issued for the tier
+	}
+
+	n.logger.Info("Tier configuration changed",
+		"tier", name,
+		"change", change.Type,
+		"fields", change.Fields,
+		"revision", revision,
+	)
+	n.publish(ctx, namespace, hash, revision, &change)
+	n.emitEvent(ctx, namespace, name, change)
+}
+
+// publish sets the tier hash and revision on the namespace and appends change, if any, to its
+// history. An empty hash removes the hash annotation, which marks the tier as no longer configured.
+// Replicas observing the same revision record it once; conflicting writes are retried.
+func (n *Notifier) publish(ctx context.Context, namespace, hash, revision string, change *Change) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ns, err := n.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if ns.Annotations == nil {
+			ns.Annotations = make(map[string]string)
+		}
+
+		if hash != "" {
+			ns.Annotations[AnnotationTierHash] = hash
+		} else {
+			delete(ns.Annotations, AnnotationTierHash)
+		}
+		ns.Annotations[AnnotationTierRevision] = revision
+
+		if change != nil {
+			history := n.parseHistory(ns)
+			recorded := slices.ContainsFunc(history, func(c Change) bool {
+				return c.Revision == change.Revision && c.Type == change.Type
+			})
+			if !recorded {
+				history = append(history, *change)
+				if len(history) > historyLimit {
+					history = history[len(history)-historyLimit:]
+				}
+				data, err := json.Marshal(history)
+				if err != nil {
+					return err
+				}
+				ns.Annotations[AnnotationTierHistory] = string(data)
+			}
+		}
+
+		_, err = n.clientset.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		n.logger.Error("Failed to annotate tier namespace",
+			"namespace", namespace,
+			"error", err,
+		)
+	}
+}
+
+// emitEvent records a TierChanged event on the namespace. The event name is derived from the
+// ConfigMap revision, so replicas observing the same change do not post duplicates.
+func (n *Notifier) emitEvent(ctx context.Context, namespace, tierName string, change Change) {
+	message := fmt.Sprintf("Tier %q %s in tier mapping revision %s", tierName, change.Type, change.Revision)
+	if len(change.Fields) > 0 {
+		message += ": " + strings.Join(change.Fields, ", ")
+	}
+
+	now := metav1.NewTime(change.ChangedAt.Time)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.tier-changed.%s", namespace, change.Revision),
+			Namespace: namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       namespace,
+		},
+		Reason:         eventReasonTierChanged,
+		Message:        message,
+		Type:           corev1.EventTypeNormal,
+		Source:         corev1.EventSource{Component: "maas-api"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	_, err := n.clientset.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		n.logger.Error("Failed to emit tier change event",
+			"namespace", namespace,
+			"error", err,
+		)
+	}
+}
+
+func tierNames(previous, current []Tier) []string {
+	var names []string
+	for _, tiers := range [][]Tier{current, previous} {
+		for i := range tiers {
+			if !slices.Contains(names, tiers[i].Name) {
+				names = append(names, tiers[i].Name)
+			}
+		}
+	}
+	return names
+}
+
+func findTier(tiers []Tier, name string) *Tier {
+	for i := range tiers {
+		if tiers[i].Name == name {
+			return &tiers[i]
+		}
+	}
+	return nil
+}
+
+func changedFields(prev, cur *Tier) []string {
+	var fields []string
+	if prev.DisplayName != cur.DisplayName {
+		fields = append(fields, "displayName")
+	}
+	if prev.Description != cur.Description {
+		fields = append(fields, "description")
+	}
+	if !slices.Equal(prev.Groups, cur.Groups) {
+		fields = append(fields, "groups")
+	}
+	if prev.Level != cur.Level {
+		fields = append(fields, "level")
+	}
+	if !slices.Equal(prev.Token.Audiences, cur.Token.Audiences) || prev.Token.MaxExpiration != cur.Token.MaxExpiration {
+		fields = append(fields, "token")
+	}
+	return fields
+}
+
+// specHash fingerprints a tier's configuration for the namespace annotation.
+func specHash(t *Tier) string {
+	data, err := yaml.Marshal(t)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,242 @@
+package tier_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tier"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/test/fixtures"
+)
+
+const withoutPremiumYAML = `
+- name: free
+  displayName: Free Tier
+  description: Free tier
+  level: 1
+  groups:
+  - system:authenticated
+  - free-users
+`
+
+func tierConfigMap(revision, tiers string) *corev1.ConfigMap {
+	cm := fixtures.CreateTierConfigMap(fixtures.TestNamespace)
+	cm.ResourceVersion = revision
+	cm.Data["tiers"] = tiers
+	return cm
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := t.Context()
+
+	freeNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fixtures.TestTenant + "-tier-free"}}
+	premiumNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fixtures.TestTenant + "-tier-premium"}}
+
+	clientset := k8sfake.NewClientset(freeNs, premiumNs)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(freeNs))
+	require.NoError(t, indexer.Add(premiumNs))
+
+	notifier := tier.NewNotifier(logger.Development(), fixtures.CreateTestMapper(true), clientset, corelisters.NewNamespaceLister(indexer))
+
+	namespace := func(t *testing.T, name string) *corev1.Namespace {
+		t.Helper()
+		ns, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		// Keep the lister in step with the API server, as the informer would.
+		require.NoError(t, indexer.Update(ns))
+		return ns
+	}
+	// sync runs a notification and refreshes the lister, from which History reads.
+	sync := func(t *testing.T, previous, current *corev1.ConfigMap) {
+		t.Helper()
+		notifier.Sync(ctx, previous, current)
+		namespace(t, freeNs.Name)
+		namespace(t, premiumNs.Name)
+	}
+	events := func(t *testing.T, namespace string) []corev1.Event {
+		t.Helper()
+		list, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return list.Items
+	}
+
+	initial := tierConfigMap("1", fixtures.TierConfigYAML)
+
+	t.Run("initial load annotates namespaces without notifying", func(t *testing.T) {
+		sync(t, nil, initial)
+
+		ns := namespace(t, freeNs.Name)
+		assert.NotEmpty(t, ns.Annotations[tier.AnnotationTierHash])
+		assert.Equal(t, "1", ns.Annotations[tier.AnnotationTierRevision])
+		assert.Empty(t, events(t, freeNs.Name))
+		assert.Empty(t, notifier.History("free"))
+	})
+
+	t.Run("initial load after a restart reports missed changes", func(t *testing.T) {
+		changed := tierConfigMap("2", strings.Replace(fixtures.TierConfigYAML, "level: 1\n", "level: 2\n", 1))
+		sync(t, nil, changed)
+
+		history := notifier.History("free")
+		require.Len(t, history, 1)
+		assert.Equal(t, tier.ChangeUpdated, history[0].Type)
+		assert.Empty(t, history[0].Fields, "previous configuration is unknown")
+		assert.Len(t, events(t, freeNs.Name), 1)
+		assert.Empty(t, notifier.History("premium"))
+
+		// Restore the baseline for the following cases.
+		sync(t, changed, tierConfigMap("3", fixtures.TierConfigYAML))
+		require.Len(t, notifier.History("free"), 2)
+	})
+
+	t.Run("update publishes changed fields", func(t *testing.T) {
+		before := namespace(t, premiumNs.Name).Annotations[tier.AnnotationTierHash]
+
+		updated := tierConfigMap("4", strings.Replace(fixtures.TierConfigYAML, "level: 10\n", "level: 12\n", 1))
+		sync(t, tierConfigMap("3", fixtures.TierConfigYAML), updated)
+
+		history := notifier.History("premium")
+		require.Len(t, history, 1)
+		assert.Equal(t, tier.ChangeUpdated, history[0].Type)
+		assert.Equal(t, []string{"level"}, history[0].Fields)
+		assert.Equal(t, "4", history[0].Revision)
+
+		ns := namespace(t, premiumNs.Name)
+		assert.NotEqual(t, before, ns.Annotations[tier.AnnotationTierHash])
+		assert.Equal(t, "4", ns.Annotations[tier.AnnotationTierRevision])
+
+		premiumEvents := events(t, premiumNs.Name)
+		require.Len(t, premiumEvents, 1)
+		assert.Equal(t, "TierChanged", premiumEvents[0].Reason)
+		assert.Contains(t, premiumEvents[0].Message, "level")
+
+		assert.Len(t, notifier.History("free"), 2, "unchanged tiers are not notified")
+	})
+
+	t.Run("resync of the same revision is ignored", func(t *testing.T) {
+		cm := tierConfigMap("4", fixtures.TierConfigYAML)
+		sync(t, cm, cm)
+		assert.Len(t, notifier.History("premium"), 1)
+	})
+
+	t.Run("removed tier clears the hash annotation", func(t *testing.T) {
+		sync(t, tierConfigMap("4", fixtures.TierConfigYAML), tierConfigMap("5", withoutPremiumYAML))
+
+		history := notifier.History("premium")
+		require.Len(t, history, 2)
+		assert.Equal(t, tier.ChangeRemoved, history[0].Type, "history is newest first")
+
+		ns := namespace(t, premiumNs.Name)
+		assert.NotContains(t, ns.Annotations, tier.AnnotationTierHash)
+		assert.Equal(t, "5", ns.Annotations[tier.AnnotationTierRevision])
+
+		// Tiers without a namespace have nowhere to keep history.
+		assert.Empty(t, notifier.History("enterprise"))
+	})
+
+	t.Run("another replica records a revision once and shares the history", func(t *testing.T) {
+		replica := tier.NewNotifier(logger.Development(), fixtures.CreateTestMapper(true), clientset, corelisters.NewNamespaceLister(indexer))
+		assert.Len(t, replica.History("premium"), 2, "history survives restarts")
+
+		replica.Sync(ctx, tierConfigMap("4", fixtures.TierConfigYAML), tierConfigMap("5", withoutPremiumYAML))
+		namespace(t, premiumNs.Name)
+		assert.Len(t, notifier.History("premium"), 2)
+	})
+
+	t.Run("invalid configuration is ignored", func(t *testing.T) {
+		sync(t, tierConfigMap("5", fixtures.TierConfigYAML), tierConfigMap("6", "- name: ''"))
+		assert.Len(t, notifier.History("free"), 2)
+	})
+}
+
+func TestNotifierInitialSyncRemovedTier(t *testing.T) {
+	ctx := t.Context()
+
+	tierNamespace := func(name, tierName, instance string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"maas.opendatahub.io/instance":       instance,
+				"maas.opendatahub.io/tier":           tierName,
+				"maas.opendatahub.io/tier-namespace": "true",
+			},
+			Annotations: map[string]string{tier.AnnotationTierHash: "deleted-while-down"},
+		}}
+	}
+	// premium was removed from the configuration while no replica was running; the namespace
+	// of another instance with the same tier name must be left alone.
+	premiumNs := tierNamespace(fixtures.TestTenant+"-tier-premium", "premium", fixtures.TestTenant)
+	otherNs := tierNamespace("other-tier-premium", "premium", "other")
+
+	clientset := k8sfake.NewClientset(premiumNs, otherNs)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(premiumNs))
+	require.NoError(t, indexer.Add(otherNs))
+
+	notifier := tier.NewNotifier(logger.Development(), fixtures.CreateTestMapper(true), clientset, corelisters.NewNamespaceLister(indexer))
+	notifier.Sync(ctx, nil, tierConfigMap("7", withoutPremiumYAML))
+
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, premiumNs.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, indexer.Update(ns))
+	assert.NotContains(t, ns.Annotations, tier.AnnotationTierHash)
+	assert.Equal(t, "7", ns.Annotations[tier.AnnotationTierRevision])
+
+	history := notifier.History("premium")
+	require.Len(t, history, 1)
+	assert.Equal(t, tier.ChangeRemoved, history[0].Type)
+	assert.Equal(t, "7", history[0].Revision)
+
+	events, err := clientset.CoreV1().Events(premiumNs.Name).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	assert.Equal(t, "TierChanged", events.Items[0].Reason)
+	assert.Contains(t, events.Items[0].Message, "removed")
+
+	other, err := clientset.CoreV1().Namespaces().Get(ctx, otherNs.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "deleted-while-down", other.Annotations[tier.AnnotationTierHash])
+
+	// With the hash annotation cleared, a later restart does not report the removal again.
+	notifier.Sync(ctx, nil, tierConfigMap("7", withoutPremiumYAML))
+	assert.Len(t, notifier.History("premium"), 1)
+}
+
+func TestNotifierRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	premiumNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        fixtures.TestTenant + "-tier-premium",
+		Annotations: map[string]string{tier.AnnotationTierHash: "outdated"},
+	}}
+	clientset := k8sfake.NewClientset(premiumNs)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(premiumNs))
+
+	notifier := tier.NewNotifier(logger.Development(), fixtures.CreateTestMapper(true), clientset, corelisters.NewNamespaceLister(indexer))
+	handler := notifier.EventHandler()
+
+	// The handler only queues the change; nothing is published until Run picks it up.
+	handler.OnAdd(tierConfigMap("1", fixtures.TierConfigYAML), true)
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, premiumNs.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "outdated", ns.Annotations[tier.AnnotationTierHash])
+
+	go notifier.Run(ctx)
+
+	assert.Eventually(t, func() bool {
+		ns, err := clientset.CoreV1().Namespaces().Get(ctx, premiumNs.Name, metav1.GetOptions{})
+		return err == nil && ns.Annotations[tier.AnnotationTierRevision] == "1"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,129 @@
+package tier
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kuadrant policies rendered by BuildRateLimitSpec.
+const (
+	RateLimitPolicyKind      = "RateLimitPolicy"
+	TokenRateLimitPolicyKind = "TokenRateLimitPolicy"
+)
+
+var (
+	// ErrInvalidRateLimit is returned when a rate limit description cannot be turned into a policy limit.
+	ErrInvalidRateLimit = errors.New("invalid rate limit")
+
+	// windowPattern is the duration format accepted by Kuadrant, e.g. "1m" or "1h30m".
+	windowPattern = regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
+	// tierNamePattern keeps the tier name safe to embed in a CEL predicate.
+	tierNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+type RateLimitSpecRequest struct {
+	Tier        string `binding:"required" json:"tier"`
+	Limit       int    `binding:"required" json:"limit"`  // Requests, or tokens when CountTokens is set, per window
+	Window      string `binding:"required" json:"window"` // Kuadrant duration, e.g. "1m"
+	Burst       *Rate  `json:"burst,omitempty"`           // Optional tighter rate over a shorter window
+	CountTokens bool   `json:"countTokens"`               // Count model tokens (TokenRateLimitPolicy) instead of requests
+}
+
+type RateLimitSpecResponse struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Name       string         `json:"name"` // Key of the entry under spec.limits
+	Limit      RateLimitEntry `json:"limit"`
+	YAML       string         `json:"yaml"` // The spec.limits entry, ready to paste into the policy
+}
+
+// RateLimitEntry is one entry of spec.limits in a Kuadrant RateLimitPolicy or TokenRateLimitPolicy.
+type RateLimitEntry struct {
+	Rates    []Rate      `json:"rates"    yaml:"rates"`
+	When     []Predicate `json:"when"     yaml:"when"`
+	Counters []Counter   `json:"counters" yaml:"counters"`
+}
+
+type Rate struct {
+	Limit  int    `json:"limit"  yaml:"limit"`
+	Window string `json:"window" yaml:"window"`
+}
+
+type Predicate struct {
+	Predicate string `json:"predicate" yaml:"predicate"`
+}
+
+type Counter struct {
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+// BuildRateLimitSpec validates req and renders the per-tier limit used by the gateway policies:
+// it applies to members of the tier and is counted per user.
+func BuildRateLimitSpec(req RateLimitSpecRequest) (*RateLimitSpecResponse, error) {
+	if !tierNamePattern.MatchString(req.Tier) {
+		return nil, fmt.Errorf("%w: tier %q must consist of lower case alphanumeric characters or '-'", ErrInvalidRateLimit, req.Tier)
+	}
+
+	main := Rate{Limit: req.Limit, Window: req.Window}
+	window, err := validateRate(main)
+	if err != nil {
+		return nil, err
+	}
+	rates := []Rate{main}
+
+	if req.Burst != nil {
+		burstWindow, err := validateRate(*req.Burst)
+		if err != nil {
+			return nil, fmt.Errorf("burst: %w", err)
+		}
+		if burstWindow >= window {
+			return nil, fmt.Errorf("%w: burst window %s must be shorter than window %s", ErrInvalidRateLimit, req.Burst.Window, req.Window)
+		}
+		if req.Burst.Limit >= req.Limit {
+			return nil, fmt.Errorf("%w: burst limit %d must be lower than limit %d", ErrInvalidRateLimit, req.Burst.Limit, req.Limit)
+		}
+		rates = append(rates, *req.Burst)
+	}
+
+	response := &RateLimitSpecResponse{
+		APIVersion: "kuadrant.io/v1",
+		Kind:       RateLimitPolicyKind,
+		Name:       req.Tier,
+		Limit: RateLimitEntry{
+			Rates:    rates,
+			When:     []Predicate{{Predicate: fmt.Sprintf("auth.identity.tier == %q", req.Tier)}},
+			Counters: []Counter{{Expression: "auth.identity.userid"}},
+		},
+	}
+	if req.CountTokens {
+		response.APIVersion = "kuadrant.io/v1alpha1"
+		response.Kind = TokenRateLimitPolicyKind
+		response.Name = req.Tier + "-user-tokens"
+	}
+
+	out, err := yaml.Marshal(map[string]RateLimitEntry{response.Name: response.Limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render rate limit YAML: %w", err)
+	}
+	response.YAML = string(out)
+
+	return response, nil
+}
+
+func validateRate(rate Rate) (time.Duration, error) {
+	if rate.Limit < 1 {
+		return 0, fmt.Errorf("%w: limit must be positive, got %d", ErrInvalidRateLimit, rate.Limit)
+	}
+	if !windowPattern.MatchString(rate.Window) {
+		return 0, fmt.Errorf("%w: window %q must be a duration such as 30s, 1m or 1h30m", ErrInvalidRateLimit, rate.Window)
+	}
+	window, err := time.ParseDuration(rate.Window)
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("%w: window %q must be positive", ErrInvalidRateLimit, rate.Window)
+	}
+	return window, nil
+}
@@ -0,0 +1,64 @@
+package tier_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tier"
+)
+
+func TestBuildRateLimitSpec(t *testing.T) {
+	t.Run("RequestLimit", func(t *testing.T) {
+		spec, err := tier.BuildRateLimitSpec(tier.RateLimitSpecRequest{Tier: "premium", Limit: 20, Window: "2m"})
+		require.NoError(t, err)
+
+		assert.Equal(t, tier.RateLimitPolicyKind, spec.Kind)
+		assert.Equal(t, "kuadrant.io/v1", spec.APIVersion)
+		assert.Equal(t, "premium", spec.Name)
+		assert.Equal(t, tier.RateLimitEntry{
+			Rates:    []tier.Rate{{Limit: 20, Window: "2m"}},
+			When:     []tier.Predicate{{Predicate: `auth.identity.tier == "premium"`}},
+			Counters: []tier.Counter{{Expression: "auth.identity.userid"}},
+		}, spec.Limit)
+
+		var rendered map[string]tier.RateLimitEntry
+		require.NoError(t, yaml.Unmarshal([]byte(spec.YAML), &rendered))
+		assert.Equal(t, map[string]tier.RateLimitEntry{"premium": spec.Limit}, rendered)
+	})
+
+	t.Run("TokenLimitWithBurst", func(t *testing.T) {
+		spec, err := tier.BuildRateLimitSpec(tier.RateLimitSpecRequest{
+			Tier:        "free",
+			Limit:       1000,
+			Window:      "1h",
+			Burst:       &tier.Rate{Limit: 100, Window: "1m"},
+			CountTokens: true,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, tier.TokenRateLimitPolicyKind, spec.Kind)
+		assert.Equal(t, "free-user-tokens", spec.Name)
+		assert.Equal(t, []tier.Rate{{Limit: 1000, Window: "1h"}, {Limit: 100, Window: "1m"}}, spec.Limit.Rates)
+	})
+
+	invalid := []struct {
+		name string
+		req  tier.RateLimitSpecRequest
+	}{
+		{"TierWithQuotes", tier.RateLimitSpecRequest{Tier: `free" || true`, Limit: 1, Window: "1m"}},
+		{"NonPositiveLimit", tier.RateLimitSpecRequest{Tier: "free", Limit: -1, Window: "1m"}},
+		{"UnknownWindowUnit", tier.RateLimitSpecRequest{Tier: "free", Limit: 1, Window: "1d"}},
+		{"ZeroWindow", tier.RateLimitSpecRequest{Tier: "free", Limit: 1, Window: "0s"}},
+		{"BurstWindowNotShorter", tier.RateLimitSpecRequest{Tier: "free", Limit: 10, Window: "1m", Burst: &tier.Rate{Limit: 5, Window: "1m"}}},
+		{"BurstLimitNotLower", tier.RateLimitSpecRequest{Tier: "free", Limit: 10, Window: "1m", Burst: &tier.Rate{Limit: 10, Window: "10s"}}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tier.BuildRateLimitSpec(tc.req)
+			require.ErrorIs(t, err, tier.ErrInvalidRateLimit)
+		})
+	}
+}
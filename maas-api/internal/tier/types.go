@@ -1,17 +1,31 @@
 package tier
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTierNotFound is returned when a tier is not present in the tier mapping.
+var ErrTierNotFound = errors.New("tier not found")
 
 // Tier represents a subscription tier with associated user groups and level.
 //
 // Level determines precedence, where higher values take precedence over lower values.
 // This can be needed in scenarios when users belong to multiple groups across different tiers.
 type Tier struct {
-	Name        string   `yaml:"name"`                  // Tier name - stable identifier (e.g., "free", "premium", "enterprise")
-	DisplayName string   `yaml:"displayName,omitempty"` // Human-friendly label (optional, falls back to Name)
-	Description string   `yaml:"description,omitempty"` // Human-readable description
-	Groups      []string `yaml:"groups"`                // List of groups that belong to this tier
-	Level       int      `yaml:"level,omitempty"`       // Level for importance (higher wins)
+	Name        string      `yaml:"name"`                  // Tier name - stable identifier (e.g., "free", "premium", "enterprise")
+	DisplayName string      `yaml:"displayName,omitempty"` // Human-friendly label (optional, falls back to Name)
+	Description string      `yaml:"description,omitempty"` // Human-readable description
+	Groups      []string    `yaml:"groups"`                // List of groups that belong to this tier
+	Level       int         `yaml:"level,omitempty"`       // Level for importance (higher wins)
+	Token       TokenPolicy `yaml:"token,omitempty"`       // Constraints on tokens issued to tier members (optional)
+}
+
+// TokenPolicy constrains the tokens and API keys issued to members of a tier.
+type TokenPolicy struct {
+	Audiences     []string      `yaml:"audiences,omitempty"`     // Audiences added to issued tokens, in addition to the instance audience
+	MaxExpiration time.Duration `yaml:"maxExpiration,omitempty"` // Longest lifetime members may request (e.g. "720h"); zero means no tier limit
 }
 
 // GroupNotFoundError indicates that a group was not found in any tier.
@@ -0,0 +1,69 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+)
+
+const (
+	AuditActionIssue  = "issue"
+	AuditActionRevoke = "revoke"
+)
+
+// AuditEvent records a single token issuance or revocation.
+type AuditEvent struct {
+	Action    string          `json:"action"`
+	Username  string          `json:"username"`
+	Namespace string          `json:"namespace"`
+	Tier      string          `json:"tier"`
+	JTI       string          `json:"jti,omitempty"`
+	ExpiresAt types.Timestamp `json:"expiresAt,omitzero"`
+	Timestamp types.Timestamp `json:"timestamp"`
+}
+
+// AuditRecorder persists audit events, stamping each with the time it was recorded.
+type AuditRecorder interface {
+	RecordAudit(ctx context.Context, event AuditEvent) error
+}
+
+// recordAudit stores the event. Failures are logged rather than returned: the token has
+// already been issued or revoked on the API server by the time the event is recorded.
+func (m *Manager) recordAudit(ctx context.Context, event AuditEvent) {
+	if m.auditRecorder == nil {
+		return
+	}
+	if err := m.auditRecorder.RecordAudit(ctx, event); err != nil {
+		m.logger.Error("Failed to record token audit event",
+			"action", event.Action,
+			"namespace", event.Namespace,
+			"error", err,
+		)
+	}
+}
+
+// CanManageTokens reports whether the user may revoke tokens in the namespace, i.e. is allowed
+// to delete service accounts there. It is used to authorize namespace admins for audit reports.
+func (m *Manager) CanManageTokens(ctx context.Context, user *UserContext, namespace string) (bool, error) {
+	review := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "delete",
+				Resource:  "serviceaccounts",
+			},
+		},
+	}
+
+	result, err := m.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access for %s in namespace %s: %w", user.Username, namespace, err)
+	}
+	return result.Status.Allowed, nil
+}
@@ -0,0 +1,46 @@
+package token_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/test/fixtures"
+)
+
+type auditRecorderStub struct {
+	mu     sync.Mutex
+	events []token.AuditEvent
+}
+
+func (r *auditRecorderStub) RecordAudit(_ context.Context, event token.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestManagerRecordsIssuanceAudit(t *testing.T) {
+	recorder := &auditRecorderStub{}
+	manager, _, cleanup := fixtures.StubTokenProviderAPIsWithAudit(t, true, recorder)
+	defer cleanup()
+
+	user := &token.UserContext{Username: "audited-user", Groups: []string{"free-users"}}
+
+	issued, err := manager.GenerateToken(t.Context(), user, 0, "")
+	require.NoError(t, err)
+
+	require.Len(t, recorder.events, 1)
+
+	issue := recorder.events[0]
+	assert.Equal(t, token.AuditActionIssue, issue.Action)
+	assert.Equal(t, "audited-user", issue.Username)
+	assert.Equal(t, "free", issue.Tier)
+	assert.Equal(t, fixtures.TestTenant+"-tier-free", issue.Namespace)
+	assert.Equal(t, issued.JTI, issue.JTI)
+	assert.Equal(t, issued.ExpiresAt, issue.ExpiresAt.Unix())
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/errcode"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 )
 
 type Handler struct {
@@ -68,10 +70,12 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 		if username == "" {
 			h.logger.Error("Missing or empty username header",
 				"header", constant.HeaderUsername,
+				"client_ip", middleware.ClientIP(c),
 			)
 			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":         "Exception thrown while generating token",
-				"exceptionCode": "AUTH_FAILURE",
+				"error":         errcode.AuthFailure.Message(),
+				"code":          string(errcode.AuthFailure),
+				"exceptionCode": string(errcode.AuthFailure),
 				"refId":         "001",
 			})
 			c.Abort()
@@ -82,10 +86,12 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 			h.logger.Error("Missing group header",
 				"header", constant.HeaderGroup,
 				"username", username,
+				"client_ip", middleware.ClientIP(c),
 			)
 			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":         "Exception thrown while generating token",
-				"exceptionCode": "AUTH_FAILURE",
+				"error":         errcode.AuthFailure.Message(),
+				"code":          string(errcode.AuthFailure),
+				"exceptionCode": string(errcode.AuthFailure),
 				"refId":         "002",
 			})
 			c.Abort()
@@ -99,11 +105,13 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 			h.logger.Error("Failed to parse group header",
 				"header", constant.HeaderGroup,
 				"header_value", groupHeader,
+				"client_ip", middleware.ClientIP(c),
 				"error", err,
 			)
 			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":         "Exception thrown while generating token",
-				"exceptionCode": "AUTH_FAILURE",
+				"error":         errcode.AuthFailure.Message(),
+				"code":          string(errcode.AuthFailure),
+				"exceptionCode": string(errcode.AuthFailure),
 				"refId":         "003",
 			})
 			c.Abort()
@@ -119,6 +127,7 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 		h.logger.Debug("Extracted user info from headers",
 			"username", username,
 			"groups", groups,
+			"client_ip", middleware.ClientIP(c),
 		)
 
 		c.Set("user", userContext)
@@ -133,7 +142,7 @@ func (h *Handler) IssueToken(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		// Allow empty request body for default expiration
 		if !errors.Is(err, io.EOF) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, errcode.InvalidRequest.ResponseWithDetail(err.Error()))
 			return
 		}
 	}
@@ -144,19 +153,19 @@ func (h *Handler) IssueToken(c *gin.Context) {
 
 	userCtx, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextMissing.Response())
 		return
 	}
 
 	user, ok := userCtx.(*UserContext)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		c.JSON(http.StatusInternalServerError, errcode.UserContextInvalid.Response())
 		return
 	}
 
 	expiration := req.Expiration.Duration
 	if err := ValidateExpiration(expiration, 10*time.Minute); err != nil {
-		response := gin.H{"error": err.Error()}
+		response := errcode.InvalidExpiration.ResponseWithDetail(err.Error())
 		if expiration > 0 && expiration < 10*time.Minute {
 			response["provided_expiration"] = expiration.String()
 		}
@@ -167,11 +176,16 @@ func (h *Handler) IssueToken(c *gin.Context) {
 	// For ephemeral tokens, we explicitly pass an empty name.
 	token, err := h.manager.GenerateToken(c.Request.Context(), user, expiration, "")
 	if err != nil {
+		var tierErr *ExpirationExceedsTierError
+		if errors.As(err, &tierErr) {
+			c.JSON(http.StatusBadRequest, errcode.InvalidExpiration.ResponseWithDetail(tierErr.Error()))
+			return
+		}
 		h.logger.Error("Failed to generate token",
 			"error", err,
 			"expiration", expiration.String(),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.JSON(errcode.TokenIssueFailed.Failure(err))
 		return
 	}
 
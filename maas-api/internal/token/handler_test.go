@@ -138,3 +138,51 @@ func TestAPIEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueToken_TierTokenPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	manager, _, cleanup := fixtures.StubTokenProviderAPIs(t, true)
+	defer cleanup()
+
+	handler := token.NewHandler(logger.Development(), fixtures.TestTenant, manager)
+
+	router := gin.New()
+	router.Use(handler.ExtractUserInfo())
+	router.POST("/v1/tokens", handler.IssueToken)
+
+	issue := func(t *testing.T, group, expiration string) (int, map[string]any) {
+		t.Helper()
+		reqBody, _ := json.Marshal(map[string]any{"expiration": expiration})
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/tokens", bytes.NewBuffer(reqBody))
+		req.Header.Set(constant.HeaderUsername, "policy-user")
+		req.Header.Set(constant.HeaderGroup, group)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	t.Run("tier audiences are added to the instance audience", func(t *testing.T) {
+		code, response := issue(t, `["enterprise-users"]`, "24h")
+		require.Equal(t, http.StatusCreated, code)
+		require.Equal(t, []any{fixtures.TestTenant + "-sa", "batch-api"}, response["audiences"])
+		require.Equal(t, "720h0m0s", response["maxExpiration"])
+	})
+
+	t.Run("expiration above the tier maximum is rejected", func(t *testing.T) {
+		code, response := issue(t, `["enterprise-users"]`, "721h")
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, "INVALID_EXPIRATION", response["code"])
+	})
+
+	t.Run("tiers without a policy keep the defaults", func(t *testing.T) {
+		code, response := issue(t, `["free-users"]`, "721h")
+		require.Equal(t, http.StatusCreated, code)
+		require.Equal(t, []any{fixtures.TestTenant + "-sa"}, response["audiences"])
+		require.NotContains(t, response, "maxExpiration")
+	})
+}
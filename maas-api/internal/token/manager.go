@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tier"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
 )
 
 type Manager struct {
@@ -28,6 +30,7 @@ type Manager struct {
 	clientset            kubernetes.Interface
 	namespaceLister      corelistersv1.NamespaceLister
 	serviceAccountLister corelistersv1.ServiceAccountLister
+	auditRecorder        AuditRecorder
 	logger               *logger.Logger
 }
 
@@ -38,6 +41,7 @@ func NewManager(
 	clientset kubernetes.Interface,
 	namespaceLister corelistersv1.NamespaceLister,
 	serviceAccountLister corelistersv1.ServiceAccountLister,
+	auditRecorder AuditRecorder,
 ) *Manager {
 	return &Manager{
 		tenantName:           tenantName,
@@ -45,6 +49,7 @@ func NewManager(
 		clientset:            clientset,
 		namespaceLister:      namespaceLister,
 		serviceAccountLister: serviceAccountLister,
+		auditRecorder:        auditRecorder,
 		logger:               log,
 	}
 }
@@ -66,6 +71,11 @@ func (m *Manager) GenerateToken(ctx context.Context, user *UserContext, expirati
 	log = log.WithFields("tier", userTier.Name)
 	log.Debug("Determined user tier")
 
+	maxExpiration := userTier.Token.MaxExpiration
+	if maxExpiration > 0 && expiration > maxExpiration {
+		return nil, &ExpirationExceedsTierError{Tier: userTier.Name, MaxExpiration: maxExpiration}
+	}
+
 	namespace, errNs := m.ensureTierNamespace(ctx, userTier.Name)
 	if errNs != nil {
 		return nil, fmt.Errorf("failed to ensure tier namespace for tier %s: %w", userTier.Name, errNs)
@@ -76,7 +86,7 @@ func (m *Manager) GenerateToken(ctx context.Context, user *UserContext, expirati
 		return nil, fmt.Errorf("failed to ensure service account for user %s in namespace %s: %w", user.Username, namespace, errSA)
 	}
 
-	token, errToken := m.createServiceAccountToken(ctx, namespace, saName, int(expiration.Seconds()))
+	token, errToken := m.createServiceAccountToken(ctx, namespace, saName, int(expiration.Seconds()), m.tokenAudiences(userTier))
 	if errToken != nil {
 		return nil, fmt.Errorf("failed to create token for service account %s in namespace %s: %w", saName, namespace, errToken)
 	}
@@ -117,8 +127,21 @@ func (m *Manager) GenerateToken(ctx context.Context, user *UserContext, expirati
 		ExpiresAt:  token.Status.ExpirationTimestamp.Unix(),
 		IssuedAt:   issuedAt,
 		JTI:        jti,
+		Audiences:  token.Spec.Audiences,
+	}
+	if maxExpiration > 0 {
+		result.MaxExpiration = &Duration{maxExpiration}
 	}
 
+	m.recordAudit(ctx, AuditEvent{
+		Action:    AuditActionIssue,
+		Username:  user.Username,
+		Namespace: namespace,
+		Tier:      userTier.Name,
+		JTI:       jti,
+		ExpiresAt: types.NewTimestamp(token.Status.ExpirationTimestamp.Time),
+	})
+
 	return result, nil
 }
 
@@ -157,6 +180,13 @@ func (m *Manager) RevokeTokens(ctx context.Context, user *UserContext) error {
 		return fmt.Errorf("failed to delete service account %s in namespace %s: %w", saName, namespace, err)
 	}
 
+	m.recordAudit(ctx, AuditEvent{
+		Action:    AuditActionRevoke,
+		Username:  user.Username,
+		Namespace: namespace,
+		Tier:      userTier.Name,
+	})
+
 	_, err = m.ensureServiceAccount(ctx, namespace, user.Username, userTier.Name)
 	if err != nil {
 		return fmt.Errorf("failed to recreate service account for user %s in namespace %s: %w", user.Username, namespace, err)
@@ -243,14 +273,26 @@ func (m *Manager) ensureServiceAccount(ctx context.Context, namespace, username,
 	return saName, nil
 }
 
+// tokenAudiences returns the instance audience, which the gateway AuthPolicy requires,
+// followed by any additional audiences configured for the tier.
+func (m *Manager) tokenAudiences(userTier *tier.Tier) []string {
+	audiences := []string{m.tenantName + "-sa"}
+	for _, audience := range userTier.Token.Audiences {
+		if !slices.Contains(audiences, audience) {
+			audiences = append(audiences, audience)
+		}
+	}
+	return audiences
+}
+
 // createServiceAccountToken creates a token for the service account using TokenRequest.
-func (m *Manager) createServiceAccountToken(ctx context.Context, namespace, saName string, ttl int) (*authv1.TokenRequest, error) {
+func (m *Manager) createServiceAccountToken(ctx context.Context, namespace, saName string, ttl int, audiences []string) (*authv1.TokenRequest, error) {
 	expirationSeconds := int64(ttl)
 
 	tokenRequest := &authv1.TokenRequest{
 		Spec: authv1.TokenRequestSpec{
 			ExpirationSeconds: &expirationSeconds,
-			Audiences:         []string{m.tenantName + "-sa"},
+			Audiences:         audiences,
 		},
 	}
 
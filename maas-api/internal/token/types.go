@@ -19,6 +19,20 @@ type Token struct {
 	ExpiresAt  int64    `json:"expiresAt"`
 	IssuedAt   int64    `json:"issuedAt,omitempty"` // JWT iat claim
 	JTI        string   `json:"jti,omitempty"`
+	// Audiences the token is valid for, including tier-specific audiences.
+	Audiences []string `json:"audiences,omitempty"`
+	// MaxExpiration is the longest lifetime the user's tier allows, if the tier sets one.
+	MaxExpiration *Duration `json:"maxExpiration,omitempty"`
+}
+
+// ExpirationExceedsTierError indicates that the requested token lifetime is longer than the user's tier allows.
+type ExpirationExceedsTierError struct {
+	Tier          string
+	MaxExpiration time.Duration
+}
+
+func (e *ExpirationExceedsTierError) Error() string {
+	return fmt.Sprintf("token expiration exceeds the maximum of %s allowed for tier %s", e.MaxExpiration, e.Tier)
 }
 
 type Duration struct {
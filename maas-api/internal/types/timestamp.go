@@ -0,0 +1,61 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timestamp is a point in time that always serializes as a UTC RFC3339 string
+// with second precision (e.g. "2025-01-02T15:04:05Z"). All API response DTOs use it
+// so clients get a single timestamp format regardless of how the value was produced.
+// The zero value serializes as null.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t, normalizing it to UTC with second precision.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t.UTC().Truncate(time.Second)}
+}
+
+// ParseTimestamp parses an RFC3339 string into a Timestamp.
+func ParseTimestamp(value string) (Timestamp, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid RFC3339 timestamp %q: %w", value, err)
+	}
+	return NewTimestamp(t), nil
+}
+
+// String returns the UTC RFC3339 representation.
+func (t Timestamp) String() string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(b, &value); err != nil {
+		return fmt.Errorf("timestamp must be an RFC3339 string: %w", err)
+	}
+
+	parsed, err := ParseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
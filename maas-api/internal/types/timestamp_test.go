@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/types"
+)
+
+func TestTimestamp(t *testing.T) {
+	t.Run("MarshalNormalizesToUTC", func(t *testing.T) {
+		loc := time.FixedZone("CEST", 2*60*60)
+		ts := types.NewTimestamp(time.Date(2025, 6, 1, 14, 30, 15, 999, loc))
+
+		data, err := json.Marshal(ts)
+		require.NoError(t, err)
+		assert.JSONEq(t, `"2025-06-01T12:30:15Z"`, string(data))
+	})
+
+	t.Run("ZeroIsNull", func(t *testing.T) {
+		data, err := json.Marshal(types.Timestamp{})
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var ts types.Timestamp
+		require.NoError(t, json.Unmarshal([]byte(`"2025-06-01T14:30:15+02:00"`), &ts))
+		assert.Equal(t, "2025-06-01T12:30:15Z", ts.String())
+
+		require.NoError(t, json.Unmarshal([]byte(`null`), &ts))
+		assert.True(t, ts.IsZero())
+	})
+
+	t.Run("RejectsNonRFC3339", func(t *testing.T) {
+		var ts types.Timestamp
+		require.Error(t, json.Unmarshal([]byte(`"01/06/2025"`), &ts))
+		require.Error(t, json.Unmarshal([]byte(`1748781015`), &ts))
+	})
+}
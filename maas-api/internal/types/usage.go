@@ -1,7 +1,5 @@
 package types
 
-import "time"
-
 // UserUsage represents aggregated usage for a specific user.
 type UserUsage struct {
 	UserID               string          `json:"user_id"`
@@ -9,7 +7,7 @@ type UserUsage struct {
 	TotalAuthorizedCalls int64           `json:"total_authorized_calls"`
 	TotalLimitedCalls    int64           `json:"total_limited_calls"`
 	TeamBreakdown        []TeamUserUsage `json:"team_breakdown"`
-	LastUpdated          time.Time       `json:"last_updated"`
+	LastUpdated          Timestamp       `json:"last_updated"`
 }
 
 // TeamUsage represents aggregated usage for a specific team (group).
@@ -21,7 +19,7 @@ type TeamUsage struct {
 	TotalAuthorizedCalls int64           `json:"total_authorized_calls"`
 	TotalLimitedCalls    int64           `json:"total_limited_calls"`
 	UserBreakdown        []UserTeamUsage `json:"user_breakdown"`
-	LastUpdated          time.Time       `json:"last_updated"`
+	LastUpdated          Timestamp       `json:"last_updated"`
 }
 
 // TeamUserUsage represents a user's usage within a specific team context.
@@ -0,0 +1,178 @@
+// Package webhook delivers signed event notifications to an external endpoint.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// Headers sent with every delivery. The signature is "sha256=" followed by the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the shared secret; receivers should reject stale timestamps.
+const (
+	HeaderEvent     = "X-MaaS-Event"
+	HeaderDelivery  = "X-MaaS-Delivery"
+	HeaderTimestamp = "X-MaaS-Timestamp"
+	HeaderSignature = "X-MaaS-Signature"
+)
+
+const (
+	queueSize       = 256
+	maxAttempts     = 3
+	initialBackoff  = time.Second
+	deliveryTimeout = 10 * time.Second
+)
+
+// Event is the JSON body of a delivery.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// Sender posts events to a webhook endpoint. Send queues events for a background worker, so that
+// callers never wait on the receiver; queued events are dropped, with a warning, when the queue is
+// full or every attempt fails. Deliver posts synchronously for callers that must know the outcome.
+type Sender struct {
+	url    string
+	secret []byte
+	client *http.Client
+	logger *logger.Logger
+
+	queue   chan Event
+	backoff time.Duration
+}
+
+// NewSender creates a sender for url, signing payloads with secret.
+func NewSender(log *logger.Logger, url, secret string) *Sender {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Sender{
+		url:     url,
+		secret:  []byte(secret),
+		client:  &http.Client{Timeout: deliveryTimeout},
+		logger:  log,
+		queue:   make(chan Event, queueSize),
+		backoff: initialBackoff,
+	}
+}
+
+// Run delivers queued events until ctx is cancelled.
+func (s *Sender) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-s.queue:
+			if err := s.deliver(ctx, event); err != nil {
+				s.logger.Warn("Dropping webhook event after failed deliveries",
+					"event", event.Type,
+					"delivery", event.ID,
+					"error", err,
+				)
+			}
+		}
+	}
+}
+
+// Send queues an event of the given type without blocking.
+func (s *Sender) Send(eventType string, data any) {
+	event := newEvent(eventType, data)
+
+	select {
+	case s.queue <- event:
+	default:
+		s.logger.Warn("Webhook queue full, dropping event",
+			"event", eventType,
+			"delivery", event.ID,
+		)
+	}
+}
+
+// Deliver posts an event of the given type, retrying like queued events, and returns an error
+// if the endpoint did not accept it.
+func (s *Sender) Deliver(ctx context.Context, eventType string, data any) error {
+	return s.deliver(ctx, newEvent(eventType, data))
+}
+
+func newEvent(eventType string, data any) Event {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return Event{
+		ID:        hex.EncodeToString(id),
+		Type:      eventType,
+		Timestamp: time.Now().UTC(),
+		Data:      data,
+	}
+}
+
+func (s *Sender) deliver(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		err := s.post(ctx, event, body)
+		if err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			return fmt.Errorf("delivery failed after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *Sender) post(ctx context.Context, event Event, body []byte) error {
+	timestamp := strconv.FormatInt(event.Timestamp.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, event.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(s.secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign computes the signature header value for a delivery.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/webhook"
+)
+
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+func TestSenderDeliversSignedEvents(t *testing.T) {
+	const secret = "s3cret"
+
+	var attempts atomic.Int32
+	received := make(chan delivery, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// Fail the first attempt to exercise the retry.
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- delivery{header: r.Header.Clone(), body: body}
+	}))
+	defer server.Close()
+
+	sender := webhook.NewSender(logger.Development(), server.URL, secret)
+	go sender.Run(t.Context())
+
+	sender.Send("api_key.created", map[string]string{"id": "key-1"})
+
+	var got delivery
+	select {
+	case got = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Equal(t, "api_key.created", got.header.Get(webhook.HeaderEvent))
+	assert.Equal(t,
+		webhook.Sign([]byte(secret), got.header.Get(webhook.HeaderTimestamp), got.body),
+		got.header.Get(webhook.HeaderSignature),
+	)
+
+	var event webhook.Event
+	require.NoError(t, json.Unmarshal(got.body, &event))
+	assert.Equal(t, got.header.Get(webhook.HeaderDelivery), event.ID)
+	assert.Equal(t, map[string]any{"id": "key-1"}, event.Data)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := webhook.Sign([]byte("secret"), "1700000000", body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.NotEqual(t, signature, webhook.Sign([]byte("other"), "1700000000", body), "secret is part of the signature")
+	assert.NotEqual(t, signature, webhook.Sign([]byte("secret"), "1700000001", body), "timestamp is part of the signature")
+}
+
+func TestSenderDeliverReportsOutcome(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sender := webhook.NewSender(logger.Development(), server.URL, "secret")
+
+	// Deliver does not need the background worker.
+	healthy.Store(true)
+	require.NoError(t, sender.Deliver(t.Context(), "api_key.expiring", map[string]string{"username": "alice"}))
+
+	healthy.Store(false)
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, sender.Deliver(ctx, "api_key.expiring", map[string]string{"username": "alice"}))
+}
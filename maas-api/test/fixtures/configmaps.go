@@ -36,6 +36,10 @@ const TierConfigYAML = `
   groups:
   - enterprise-users
   - admin-users
+  token:
+    audiences:
+    - batch-api
+    maxExpiration: 720h
 `
 
 // CreateTierConfigMap creates a ConfigMap with tier configuration.
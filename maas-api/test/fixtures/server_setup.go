@@ -113,7 +113,13 @@ func SetupTestServer(_ *testing.T, config TestServerConfig) (*gin.Engine, *TestC
 }
 
 // StubTokenProviderAPIs creates common test components for token tests.
-func StubTokenProviderAPIs(_ *testing.T, withTierConfig bool) (*token.Manager, *k8sfake.Clientset, func()) {
+func StubTokenProviderAPIs(t *testing.T, withTierConfig bool) (*token.Manager, *k8sfake.Clientset, func()) {
+	t.Helper()
+	return StubTokenProviderAPIsWithAudit(t, withTierConfig, nil)
+}
+
+// StubTokenProviderAPIsWithAudit is StubTokenProviderAPIs with issuance and revocation events sent to recorder.
+func StubTokenProviderAPIsWithAudit(_ *testing.T, withTierConfig bool, recorder token.AuditRecorder) (*token.Manager, *k8sfake.Clientset, func()) {
 	testLogger := logger.Development()
 
 	var objects []runtime.Object
@@ -142,6 +148,7 @@ func StubTokenProviderAPIs(_ *testing.T, withTierConfig bool) (*token.Manager, *
 		fakeClient,
 		namespaceLister,
 		serviceAccountLister,
+		recorder,
 	)
 
 	cleanup := func() {}
@@ -163,7 +170,7 @@ func SetupTestRouter(manager *token.Manager) (*gin.Engine, func() error) {
 	}
 
 	tokenHandler := token.NewHandler(testLogger, "test", manager)
-	apiKeyService := api_keys.NewService(manager, store)
+	apiKeyService := api_keys.NewService(manager, store, 0, 0, nil)
 	apiKeyHandler := api_keys.NewHandler(testLogger, apiKeyService)
 
 	protected := router.Group("/v1")
@@ -183,8 +190,10 @@ func SetupTierTestRouter(mapper *tier.Mapper) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	handler := tier.NewHandler(mapper)
+	handler := tier.NewHandler(logger.Development(), mapper, nil)
 	router.POST("/tiers/lookup", handler.TierLookup)
+	router.GET("/tiers/:name", handler.GetTier)
+	router.POST("/tools/ratelimit-spec", handler.RateLimitSpec)
 
 	return router
 }